@@ -0,0 +1,92 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+// Bounded is a doubly ended heap that holds at most a fixed number of
+// elements.  When it is full, offering an element that is smaller than the
+// largest element evicts the largest element, so a Bounded keeps the
+// smallest elements it has been offered.  To keep the largest elements
+// instead, order it with a reversed comparison function.
+type Bounded[T any] struct {
+	d        Deheap[T]
+	capacity int
+}
+
+// NewBounded returns an empty Bounded ordered by cmp that holds at most
+// capacity elements.  See New().
+func NewBounded[T any](capacity int, cmp func(a, b T) int) *Bounded[T] {
+	if capacity < 0 {
+		panic("deheap: negative capacity")
+	}
+	return &Bounded[T]{
+		d:        Deheap[T]{data: make([]T, 0, capacity), cmp: cmp},
+		capacity: capacity,
+	}
+}
+
+// Len returns the number of elements in the deheap.
+func (b *Bounded[T]) Len() int {
+	return b.d.Len()
+}
+
+// Cap returns the maximum number of elements the deheap holds.
+func (b *Bounded[T]) Cap() int {
+	return b.capacity
+}
+
+// Offer adds x to the deheap if it is not full, or if x is smaller than its
+// largest element.  When the deheap is full, Offer returns the element that
+// did not fit with didEvict set: either the previous largest element, which
+// x replaces, or x itself when it was rejected.
+// Time complexity is O(log n), where n = b.Len()
+func (b *Bounded[T]) Offer(x T) (evicted T, didEvict bool) {
+	l := b.d.Len()
+	if l < b.capacity {
+		b.d.Push(x)
+		return evicted, false
+	}
+	return b.d.PushPopMax(x), true
+}
+
+// PeekMin returns the smallest element without removing it.
+func (b *Bounded[T]) PeekMin() T {
+	return b.d.PeekMin()
+}
+
+// PeekMax returns the largest element without removing it.
+func (b *Bounded[T]) PeekMax() T {
+	return b.d.PeekMax()
+}
+
+// PopMin removes and returns the smallest element.
+// Time complexity is O(log n), where n = b.Len()
+func (b *Bounded[T]) PopMin() T {
+	return b.d.PopMin()
+}
+
+// PopMax removes and returns the largest element.
+// Time complexity is O(log n), where n = b.Len()
+func (b *Bounded[T]) PopMax() T {
+	return b.d.PopMax()
+}
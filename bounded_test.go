@@ -0,0 +1,138 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestBoundedOffer(t *testing.T) {
+
+	b := NewBounded(3, intCmp)
+	ts := []struct {
+		x        int
+		evicted  int
+		didEvict bool
+	}{
+		{5, 0, false},
+		{3, 0, false},
+		{8, 0, false},
+		{9, 9, true},
+		{8, 8, true},
+		{1, 8, true},
+		{4, 5, true},
+		{2, 4, true},
+	}
+	for i, tv := range ts {
+		evicted, didEvict := b.Offer(tv.x)
+		if evicted != tv.evicted || didEvict != tv.didEvict {
+			t.Fatalf("unexpected value: %d %d %v", i, evicted, didEvict)
+		}
+	}
+	if b.Len() != 3 || b.Cap() != 3 {
+		t.Fatalf("unexpected value: %d %d", b.Len(), b.Cap())
+	}
+	if x := b.PopMax(); x != 3 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if x := b.PopMin(); x != 1 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+
+	b = NewBounded(0, intCmp)
+	if x, ok := b.Offer(1); x != 1 || !ok {
+		t.Fatalf("unexpected value: %d %v", x, ok)
+	}
+
+}
+
+func TestBoundedRandom(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		K := s.Intn(16) + 1
+		b := NewBounded(K, intCmp)
+		all := []int{}
+		n := s.Intn(256)
+		for i := 0; i < n; i++ {
+			x := s.Intn(64)
+			all = append(all, x)
+			b.Offer(x)
+			if x, y, ok := isDeheap(t, b.d.slice()); !ok {
+				t.Fatalf("unexpected value: %d %d", x, y)
+			}
+		}
+		sort.Ints(all)
+		if len(all) > K {
+			all = all[:K]
+		}
+		for i := len(all) - 1; i >= 0; i-- {
+			if x := b.PopMax(); x != all[i] {
+				t.Fatalf("unexpected value: %d %d", x, all[i])
+			}
+		}
+	}
+
+}
+
+func BenchmarkBoundedOffer(b *testing.B) {
+
+	s := _newRand()
+	r := make([]int, b.N)
+	for i := range r {
+		r[i] = s.Int()
+	}
+
+	q := NewBounded(1024, intCmp)
+
+	b.ResetTimer()
+
+	for _, x := range r {
+		q.Offer(x)
+	}
+
+}
+
+func BenchmarkBoundedPushPopMax(b *testing.B) {
+
+	s := _newRand()
+	r := make([]int, b.N)
+	for i := range r {
+		r[i] = s.Int()
+	}
+
+	q := New(intCmp)
+
+	b.ResetTimer()
+
+	for _, x := range r {
+		q.Push(x)
+		if q.Len() > 1024 {
+			q.PopMax()
+		}
+	}
+
+}
@@ -0,0 +1,155 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"container/heap"
+	"fmt"
+)
+
+// A ComparisonError reports comparisons by Less that are not consistent
+// with a strict weak ordering.
+type ComparisonError struct {
+	// Rule is the rule that was broken: "irreflexivity", "asymmetry",
+	// "transitivity" or "transitivity of equivalence"
+	Rule string
+	// Indexes holds the indexes of the elements compared
+	Indexes []int
+	// Values holds the elements compared, when the Checker has a Value
+	// function
+	Values []interface{}
+}
+
+func (e *ComparisonError) Error() string {
+	if e.Values != nil {
+		return fmt.Sprintf("deheap: Less violates %s for elements %v at indexes %v", e.Rule, e.Values, e.Indexes)
+	}
+	return fmt.Sprintf("deheap: Less violates %s for elements at indexes %v", e.Rule, e.Indexes)
+}
+
+// Checker wraps a heap.Interface and checks a sample of the comparisons
+// made through it by the deheap functions.  For each sampled comparison of
+// two elements, the two elements and a third one picked at random are
+// compared with each other and with themselves, and any comparison that is
+// not irreflexive, asymmetric and transitive, with transitive equivalence,
+// is reported.  NaN floating point values are a common cause of intransitive
+// equivalence.
+//
+// A Checker is used in place of the heap.Interface it wraps:
+//
+//	c := &deheap.Checker{Interface: h, Every: 100, Report: func(err *deheap.ComparisonError) { log.Print(err) }}
+//	deheap.Push(c, x)
+type Checker struct {
+	heap.Interface
+	// Every is the sampling interval: one comparison in Every is checked.
+	// Every comparison is checked when Every is less than 2.
+	Every int
+	// Value, if not nil, returns the element at index i, for reporting.
+	Value func(i int) interface{}
+	// Report is called with every violation found.  If Report is nil, the
+	// Checker panics with the violation instead.
+	Report func(err *ComparisonError)
+
+	n    int
+	rand uint64
+}
+
+// Less compares the elements at i and j with the wrapped Less, checking the
+// comparison if it is sampled.
+func (c *Checker) Less(i, j int) bool {
+	q := c.Interface.Less(i, j)
+	c.n++
+	if c.n >= c.Every {
+		c.n = 0
+		c.check(i, j, c.pick())
+	}
+	return q
+}
+
+// pick returns a random index for the third element of a check
+func (c *Checker) pick() int {
+	// xorshift64
+	if c.rand == 0 {
+		c.rand = 0x9e3779b97f4a7c15
+	}
+	c.rand ^= c.rand << 13
+	c.rand ^= c.rand >> 7
+	c.rand ^= c.rand << 17
+	return int(c.rand % uint64(c.Interface.Len()))
+}
+
+// check compares i, j and k with each other and with themselves
+func (c *Checker) check(i, j, k int) {
+	h := c.Interface
+	ix := [3]int{i, j, k}
+	var lt [3][3]bool
+	for a := 0; a < 3; a++ {
+		for b := 0; b < 3; b++ {
+			lt[a][b] = h.Less(ix[a], ix[b])
+		}
+		if lt[a][a] {
+			c.report("irreflexivity", ix[a])
+			return
+		}
+	}
+	for a := 0; a < 3; a++ {
+		for b := a + 1; b < 3; b++ {
+			if lt[a][b] && lt[b][a] {
+				c.report("asymmetry", ix[a], ix[b])
+				return
+			}
+		}
+	}
+	eq := func(a, b int) bool { return !lt[a][b] && !lt[b][a] }
+	for a := 0; a < 3; a++ {
+		for b := 0; b < 3; b++ {
+			if a == b {
+				continue
+			}
+			// d is the third of the three elements
+			d := 3 - a - b
+			if lt[a][b] && lt[b][d] && !lt[a][d] {
+				c.report("transitivity", ix[a], ix[b], ix[d])
+				return
+			}
+			if eq(a, b) && eq(b, d) && !eq(a, d) {
+				c.report("transitivity of equivalence", ix[a], ix[b], ix[d])
+				return
+			}
+		}
+	}
+}
+
+func (c *Checker) report(rule string, ix ...int) {
+	err := &ComparisonError{Rule: rule, Indexes: ix}
+	if c.Value != nil {
+		for _, i := range ix {
+			err.Values = append(err.Values, c.Value(i))
+		}
+	}
+	if c.Report == nil {
+		panic(err)
+	}
+	c.Report(err)
+}
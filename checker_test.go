@@ -0,0 +1,140 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"errors"
+	"math"
+	"sort"
+	"testing"
+)
+
+type floatHeap []float64
+
+func (h floatHeap) Len() int            { return len(h) }
+func (h floatHeap) Less(i, j int) bool  { return h[i] < h[j] }
+func (h floatHeap) Swap(i, j int)       { h[i], h[j] = h[j], h[i] }
+func (h *floatHeap) Push(x interface{}) { *h = append(*h, x.(float64)) }
+func (h *floatHeap) Pop() interface{} {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// lessEqualHeap is an IntHeap whose Less is not irreflexive
+type lessEqualHeap struct {
+	IntHeap
+}
+
+func (h *lessEqualHeap) Less(i, j int) bool {
+	return h.IntHeap[i] <= h.IntHeap[j]
+}
+
+func TestCheckerValid(t *testing.T) {
+
+	h := &IntHeap{}
+	c := &Checker{Interface: h, Report: func(err *ComparisonError) {
+		t.Fatalf("unexpected value: %v", err)
+	}}
+	s := _newRand()
+	for i := 0; i < 1000; i++ {
+		Push(c, s.Intn(100))
+	}
+	for h.Len() > 0 {
+		PopMax(c)
+	}
+
+}
+
+func TestCheckerNaN(t *testing.T) {
+
+	h := &floatHeap{}
+	errs := []*ComparisonError{}
+	c := &Checker{
+		Interface: h,
+		Value:     func(i int) interface{} { return (*h)[i] },
+		Report:    func(err *ComparisonError) { errs = append(errs, err) },
+	}
+	for i := 0; i < 100; i++ {
+		Push(c, float64(i))
+		if i%10 == 0 {
+			Push(c, math.NaN())
+		}
+	}
+	if len(errs) == 0 {
+		t.Fatalf("unexpected value")
+	}
+	for _, err := range errs {
+		if err.Rule != "transitivity of equivalence" || len(err.Values) != 3 {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+
+}
+
+func TestCheckerIrreflexive(t *testing.T) {
+
+	h := &lessEqualHeap{}
+	c := &Checker{Interface: h, Every: 10}
+
+	defer func() {
+		err, ok := recover().(error)
+		var ce *ComparisonError
+		if !ok || !errors.As(err, &ce) || ce.Rule != "irreflexivity" {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}()
+	for i := 0; i < 100; i++ {
+		Push(c, i%7)
+	}
+
+}
+
+func TestCheckerRules(t *testing.T) {
+
+	// a rock, paper, scissors ordering
+	rps := func(a, b int) bool { return (a+1)%3 == b }
+	h := &IntHeap{0, 1, 2}
+	var got *ComparisonError
+	c := &Checker{Interface: &rpsHeap{h, rps}, Report: func(err *ComparisonError) { got = err }}
+	c.check(0, 1, 2)
+	if got == nil || got.Rule != "transitivity" {
+		t.Fatalf("unexpected value: %v", got)
+	}
+	sort.Ints(got.Indexes)
+	if got.Indexes[0] != 0 || got.Indexes[1] != 1 || got.Indexes[2] != 2 {
+		t.Fatalf("unexpected value: %v", got)
+	}
+
+}
+
+type rpsHeap struct {
+	*IntHeap
+	less func(a, b int) bool
+}
+
+func (h *rpsHeap) Less(i, j int) bool {
+	return h.less((*h.IntHeap)[i], (*h.IntHeap)[j])
+}
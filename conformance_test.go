@@ -0,0 +1,186 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+// The conformance tests and benchmarks run against every PriorityQueue
+// implementation.  Implementations with a Verify method are checked after
+// every operation.
+
+var priorityQueues = []struct {
+	name string
+	new  func(cmp func(a, b int) int) PriorityQueue[int]
+}{
+	{"Deheap", func(cmp func(a, b int) int) PriorityQueue[int] { return New(cmp) }},
+	{"QuadDeheap", func(cmp func(a, b int) int) PriorityQueue[int] { return NewQuad(cmp) }},
+	{"IntervalHeap", func(cmp func(a, b int) int) PriorityQueue[int] { return NewInterval(cmp) }},
+	{"SymmetricHeap", func(cmp func(a, b int) int) PriorityQueue[int] { return NewSymmetric(cmp) }},
+}
+
+func verifyQueue(t *testing.T, q PriorityQueue[int], ref []int) {
+	t.Helper()
+	if v, ok := q.(interface{ Verify() error }); ok {
+		if err := v.Verify(); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+	if q.Len() != len(ref) {
+		t.Fatalf("unexpected value: %d %d", q.Len(), len(ref))
+	}
+	if len(ref) > 0 && (q.PeekMin() != ref[0] || q.PeekMax() != ref[len(ref)-1]) {
+		t.Fatalf("unexpected value: %d %d %d %d", q.PeekMin(), q.PeekMax(), ref[0], ref[len(ref)-1])
+	}
+}
+
+func TestPriorityQueueEmpty(t *testing.T) {
+
+	for _, pq := range priorityQueues {
+		t.Run(pq.name, func(t *testing.T) {
+			q := pq.new(intCmp)
+			for _, f := range []func(){
+				func() { q.PeekMin() },
+				func() { q.PeekMax() },
+				func() { q.PopMin() },
+				func() { q.PopMax() },
+			} {
+				func() {
+					defer func() {
+						if err, ok := recover().(error); !ok || !errors.Is(err, ErrEmpty) {
+							t.Fatalf("unexpected value: %v", err)
+						}
+					}()
+					f()
+				}()
+			}
+			q.Push(1)
+			if q.PeekMin() != 1 || q.PeekMax() != 1 || q.PopMax() != 1 || q.Len() != 0 {
+				t.Fatalf("unexpected value: %d", q.Len())
+			}
+		})
+	}
+
+}
+
+func TestPriorityQueueSorted(t *testing.T) {
+
+	for _, pq := range priorityQueues {
+		t.Run(pq.name, func(t *testing.T) {
+			for _, n := range []int{1, 2, 3, 7, 8, 100} {
+				q := pq.new(intCmp)
+				for i := 0; i < n; i++ {
+					q.Push(i)
+				}
+				for i := n - 1; i >= 0; i-- {
+					q.Push(i)
+				}
+				for i := 0; i < n; i++ {
+					if x, y := q.PopMin(), q.PopMax(); x != i/2 || y != n-1-i/2 {
+						t.Fatalf("unexpected value: %d %d %d", i, x, y)
+					}
+				}
+				if q.Len() != 0 {
+					t.Fatalf("unexpected value: %d", q.Len())
+				}
+			}
+		})
+	}
+
+}
+
+func TestPriorityQueueRandom(t *testing.T) {
+
+	for _, pq := range priorityQueues {
+		t.Run(pq.name, func(t *testing.T) {
+			s := _newRand()
+			for k := 0; k < 200; k++ {
+				q := pq.new(intCmp)
+				ref := []int{}
+				for i := 0; i < 300; i++ {
+					switch op := s.Intn(5); {
+					case op < 3 || len(ref) == 0:
+						x := s.Intn(100)
+						q.Push(x)
+						ref = append(ref, x)
+						sort.Ints(ref)
+					case op == 3:
+						if x := q.PopMin(); x != ref[0] {
+							t.Fatalf("unexpected value: %d %d", x, ref[0])
+						}
+						ref = ref[1:]
+					default:
+						if x := q.PopMax(); x != ref[len(ref)-1] {
+							t.Fatalf("unexpected value: %d %d", x, ref[len(ref)-1])
+						}
+						ref = ref[:len(ref)-1]
+					}
+					verifyQueue(t, q, ref)
+				}
+			}
+		})
+	}
+
+}
+
+// BenchmarkPriorityQueuePopMin and BenchmarkPriorityQueuePopMax keep a
+// queue of 1e5 random numbers, pushing a new random number after every pop.
+// BenchmarkPriorityQueueMixed pops from either end at random.
+
+func benchmarkPriorityQueue(b *testing.B, pop func(q PriorityQueue[int], i int) int) {
+	for _, pq := range priorityQueues {
+		b.Run(pq.name, func(b *testing.B) {
+			q := pq.new(intCmp)
+			for _, x := range randInts(b, 1e5) {
+				q.Push(x)
+			}
+			r := randInts(b, 1<<16)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pop(q, r[i&(1<<16-1)])
+				q.Push(r[i&(1<<16-1)])
+			}
+		})
+	}
+}
+
+func BenchmarkPriorityQueuePopMin(b *testing.B) {
+	benchmarkPriorityQueue(b, func(q PriorityQueue[int], i int) int { return q.PopMin() })
+}
+
+func BenchmarkPriorityQueuePopMax(b *testing.B) {
+	benchmarkPriorityQueue(b, func(q PriorityQueue[int], i int) int { return q.PopMax() })
+}
+
+func BenchmarkPriorityQueueMixed(b *testing.B) {
+	benchmarkPriorityQueue(b, func(q PriorityQueue[int], i int) int {
+		if i&1 == 0 {
+			return q.PopMin()
+		}
+		return q.PopMax()
+	})
+}
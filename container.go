@@ -0,0 +1,234 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+// PriorityQueue is the set of operations shared by Deheap, QuadDeheap,
+// IntervalHeap and SymmetricHeap, so that code can be written once and run against whichever
+// implementation suits its workload.
+type PriorityQueue[T any] interface {
+	// Len returns the number of elements.
+	Len() int
+	// Push adds x.
+	Push(x T)
+	// PeekMin returns the smallest element without removing it.
+	PeekMin() T
+	// PeekMax returns the largest element without removing it.
+	PeekMax() T
+	// PopMin removes and returns the smallest element.
+	PopMin() T
+	// PopMax removes and returns the largest element.
+	PopMax() T
+}
+
+// Deheap is a type-safe doubly ended heap of elements of type T.
+//
+// A Deheap is ordered by the comparison function given to New and keeps its
+// elements in a slice it manages itself, so no Len, Less, Swap, Push or Pop
+// methods need to be written and no values are boxed in interfaces.
+type Deheap[T any] struct {
+	data []T
+	cmp  func(a, b T) int
+}
+
+// New returns an empty Deheap ordered by cmp.  cmp(a, b) should return a
+// negative number when a < b, a positive number when a > b and zero when
+// a == b, as with the comparison functions of the slices package.
+func New[T any](cmp func(a, b T) int) *Deheap[T] {
+	return &Deheap[T]{cmp: cmp}
+}
+
+// NewLess returns an empty Deheap ordered by less.
+func NewLess[T any](less func(a, b T) bool) *Deheap[T] {
+	return New(func(a, b T) int {
+		if less(a, b) {
+			return -1
+		}
+		if less(b, a) {
+			return 1
+		}
+		return 0
+	})
+}
+
+// deheapSlice adapts a Deheap to the sort.Interface used by the deheap
+// algorithms
+type deheapSlice[T any] Deheap[T]
+
+func (d *deheapSlice[T]) Len() int           { return len(d.data) }
+func (d *deheapSlice[T]) Less(i, j int) bool { return d.cmp(d.data[i], d.data[j]) < 0 }
+func (d *deheapSlice[T]) Swap(i, j int)      { d.data[i], d.data[j] = d.data[j], d.data[i] }
+
+func (d *Deheap[T]) slice() *deheapSlice[T] {
+	return (*deheapSlice[T])(d)
+}
+
+// Len returns the number of elements in the deheap.
+func (d *Deheap[T]) Len() int {
+	return len(d.data)
+}
+
+// At returns the element at index i.  Elements are stored in heap order,
+// so At(0) is the smallest element.
+func (d *Deheap[T]) At(i int) T {
+	checkIndex(i, len(d.data), "At")
+	return d.data[i]
+}
+
+// Push an element onto the deheap.
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) Push(x T) {
+	d.data = pushFunc(d.data, d.cmp, x)
+	if debug {
+		debugf(d.slice(), "Push(%v)", x)
+	}
+}
+
+// PushSlice pushes all of items onto the deheap.  See PushAll().
+// Time complexity is O(min(k log(n+k), n+k)), where n = d.Len() and k = len(items)
+func (d *Deheap[T]) PushSlice(items []T) {
+	n := len(d.data)
+	d.data = append(d.data, items...)
+	appended(d.slice(), n, len(d.data))
+	if debug {
+		debugf(d.slice(), "PushSlice(%d elements)", len(items))
+	}
+}
+
+// PeekMin returns the smallest element without removing it.
+func (d *Deheap[T]) PeekMin() T {
+	checkEmpty(len(d.data), "PeekMin")
+	return d.data[0]
+}
+
+// PeekMax returns the largest element without removing it.
+func (d *Deheap[T]) PeekMax() T {
+	checkEmpty(len(d.data), "PeekMax")
+	return d.data[maxIndexFunc(d.data, d.cmp)]
+}
+
+// PopMin removes and returns the smallest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) PopMin() T {
+	checkEmpty(len(d.data), "PopMin")
+	return d.Remove(0)
+}
+
+// PopMax removes and returns the largest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) PopMax() T {
+	checkEmpty(len(d.data), "PopMax")
+	return d.Remove(maxIndexFunc(d.data, d.cmp))
+}
+
+// PushPopMin pushes x and pops the smallest element.  See PushPopMin().
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) PushPopMin(x T) T {
+	if len(d.data) == 0 || d.cmp(x, d.data[0]) <= 0 {
+		return x
+	}
+	return d.ReplaceMin(x)
+}
+
+// PushPopMax pushes x and pops the largest element.  See PushPopMax().
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) PushPopMax(x T) T {
+	if len(d.data) == 0 || d.cmp(x, d.data[maxIndexFunc(d.data, d.cmp)]) >= 0 {
+		return x
+	}
+	return d.ReplaceMax(x)
+}
+
+// ReplaceMin pops the smallest element and pushes x.  See ReplaceMin().
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) ReplaceMin(x T) T {
+	checkEmpty(len(d.data), "ReplaceMin")
+	q := d.data[0]
+	d.data[0] = x
+	bubbledownFunc(d.data, d.cmp, true, 0)
+	if debug {
+		debugf(d.slice(), "ReplaceMin(%v) = %v", x, q)
+	}
+	return q
+}
+
+// ReplaceMax pops the largest element and pushes x.  See ReplaceMax().
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) ReplaceMax(x T) T {
+	checkEmpty(len(d.data), "ReplaceMax")
+	j := maxIndexFunc(d.data, d.cmp)
+	q := d.data[j]
+	d.data[j] = x
+	fixFunc(d.data, d.cmp, j)
+	if debug {
+		debugf(d.slice(), "ReplaceMax(%v) = %v", x, q)
+	}
+	return q
+}
+
+// Remove removes and returns the element at index i.
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) Remove(i int) T {
+	checkIndex(i, len(d.data), "Remove")
+	var x T
+	d.data, x = removeFunc(d.data, d.cmp, i)
+	if debug {
+		debugf(d.slice(), "Remove(%d) = %v", i, x)
+	}
+	return x
+}
+
+// Fix re-establishes the ordering after the element at index i has changed
+// its value, for instance through a pointer held by the caller.  See Fix().
+// Time complexity is O(log n), where n = d.Len()
+func (d *Deheap[T]) Fix(i int) {
+	checkIndex(i, len(d.data), "Fix")
+	fixFunc(d.data, d.cmp, i)
+	if debug {
+		debugf(d.slice(), "Fix(%d)", i)
+	}
+}
+
+// Meld moves all the elements of src onto d, leaving src empty.  src must be
+// ordered the same way as d.  The smaller of the two deheaps is added to the
+// larger one, either one element at a time or by rebuilding, depending on
+// their relative sizes.  Melding a deheap with itself leaves it unchanged.
+// See Meld().
+// Time complexity is O(min(m log(n+m), n+m)), where m is the size of the
+// smaller deheap and n the size of the larger
+func (d *Deheap[T]) Meld(src *Deheap[T]) {
+	if src == d {
+		return
+	}
+	if len(src.data) > len(d.data) {
+		d.data, src.data = src.data, d.data
+	}
+	n := len(d.data)
+	d.data = append(d.data, src.data...)
+	clear(src.data)
+	src.data = src.data[:0]
+	appended(d.slice(), n, len(d.data))
+	if debug {
+		debugf(d.slice(), "Meld(%d elements)", len(d.data)-n)
+	}
+}
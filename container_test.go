@@ -0,0 +1,423 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"sort"
+	"testing"
+)
+
+func intCmp(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	}
+	return 0
+}
+
+func TestDeheapPushPop(t *testing.T) {
+
+	d := New(intCmp)
+	in := []int{0, 10, 9, 8, 7, 6, 5, 5, 4, 7, 3, 2, 1}
+	for _, v := range in {
+		d.Push(v)
+		if x, y, ok := isDeheap(t, d.slice()); !ok {
+			t.Fatalf("unexpected value: %d %d %v", x, y, d.data)
+		}
+	}
+	if d.Len() != len(in) {
+		t.Fatalf("unexpected value: %d", d.Len())
+	}
+	if d.PeekMin() != 0 {
+		t.Fatalf("unexpected value: %d", d.PeekMin())
+	}
+	if d.PeekMax() != 10 {
+		t.Fatalf("unexpected value: %d", d.PeekMax())
+	}
+
+	out := []int{0, 10, 1, 9, 2, 8, 3, 7, 4, 7, 5, 6, 5}
+	for i, v := range out {
+		var x int
+		if i%2 == 0 {
+			x = d.PopMin()
+		} else {
+			x = d.PopMax()
+		}
+		if x != v {
+			t.Fatalf("unexpected value: %d %d", i, x)
+		}
+		if x, y, ok := isDeheap(t, d.slice()); !ok {
+			t.Fatalf("unexpected value: %d %d %v", x, y, d.data)
+		}
+	}
+	if d.Len() != 0 {
+		t.Fatalf("unexpected value: %d", d.Len())
+	}
+
+}
+
+func TestDeheapNewLess(t *testing.T) {
+
+	d := NewLess(func(a, b string) bool { return a < b })
+	for _, v := range []string{"pear", "apple", "fig", "banana", "cherry"} {
+		d.Push(v)
+	}
+	if x := d.PopMax(); x != "pear" {
+		t.Fatalf("unexpected value: %s", x)
+	}
+	if x := d.PopMin(); x != "apple" {
+		t.Fatalf("unexpected value: %s", x)
+	}
+	if x := d.PopMin(); x != "banana" {
+		t.Fatalf("unexpected value: %s", x)
+	}
+
+}
+
+func TestDeheapRemove(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		d := New(intCmp)
+		N := s.Intn(64) + 1
+		ref := []int{}
+		for i := 0; i < N; i++ {
+			v := s.Intn(N)
+			d.Push(v)
+			ref = append(ref, v)
+		}
+		for d.Len() > 0 {
+			i := s.Intn(d.Len())
+			x := d.Remove(i)
+			j := 0
+			for ref[j] != x {
+				j++
+			}
+			ref = append(ref[:j], ref[j+1:]...)
+			if x, y, ok := isDeheap(t, d.slice()); !ok {
+				t.Fatalf("unexpected value: %d %d %v", x, y, d.data)
+			}
+		}
+		if len(ref) != 0 {
+			t.Fatalf("unexpected value: %v", ref)
+		}
+	}
+
+}
+
+func TestDeheapFix(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		d := New(func(a, b *int) int { return intCmp(*a, *b) })
+		N := s.Intn(64) + 1
+		for i := 0; i < N; i++ {
+			v := s.Intn(N)
+			d.Push(&v)
+		}
+		for j := 0; j < N; j++ {
+			i := s.Intn(d.Len())
+			*d.At(i) = s.Intn(N)
+			d.Fix(i)
+			if x, y, ok := isDeheap(t, d.slice()); !ok {
+				t.Fatalf("unexpected value: %d %d", x, y)
+			}
+		}
+		out := []int{}
+		for d.Len() > 0 {
+			out = append(out, *d.PopMin())
+		}
+		if !sort.IntsAreSorted(out) {
+			t.Fatalf("unexpected value: %v", out)
+		}
+	}
+
+}
+
+func TestDeheapMeld(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 500; k++ {
+		d0 := New(intCmp)
+		d1 := New(intCmp)
+		ref := []int{}
+		for i := s.Intn(100); i > 0; i-- {
+			x := s.Intn(50)
+			d0.Push(x)
+			ref = append(ref, x)
+		}
+		for i := s.Intn(100); i > 0; i-- {
+			x := s.Intn(50)
+			d1.Push(x)
+			ref = append(ref, x)
+		}
+		sort.Ints(ref)
+		d0.Meld(d1)
+		if d1.Len() != 0 {
+			t.Fatalf("unexpected value: %d", d1.Len())
+		}
+		if x, y, ok := isDeheap(t, d0.slice()); !ok {
+			t.Fatalf("unexpected value: %d %d %v", x, y, d0.data)
+		}
+		for _, y := range ref {
+			if x := d0.PopMin(); x != y {
+				t.Fatalf("unexpected value: %d %d", x, y)
+			}
+		}
+	}
+
+	d := New(intCmp)
+	for i := 0; i < 10; i++ {
+		d.Push(i)
+	}
+	d.Meld(d)
+	if d.Len() != 10 || d.PeekMin() != 0 || d.PeekMax() != 9 {
+		t.Fatalf("unexpected value: %v", d.data)
+	}
+
+}
+
+func TestDeheapPushSlice(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 500; k++ {
+		N := s.Intn(100)
+		M := s.Intn(100)
+		if k%2 == 0 {
+			N = s.Intn(4)
+			M = 1000 + s.Intn(1000)
+		}
+		d := New(intCmp)
+		ref := []int{}
+		for i := 0; i < N; i++ {
+			x := s.Intn(50)
+			d.Push(x)
+			ref = append(ref, x)
+		}
+		items := make([]int, M)
+		for i := range items {
+			items[i] = s.Intn(50)
+		}
+		ref = append(ref, items...)
+		sort.Ints(ref)
+		d.PushSlice(items)
+		if x, y, ok := isDeheap(t, d.slice()); !ok {
+			t.Fatalf("unexpected value: %d %d %v", x, y, d.data)
+		}
+		for _, y := range ref {
+			if x := d.PopMin(); x != y {
+				t.Fatalf("unexpected value: %d %d", x, y)
+			}
+		}
+	}
+
+}
+
+func TestDeheapPushPopReplace(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 500; k++ {
+		d := New(intCmp)
+		ref := []int{}
+		for i := s.Intn(50); i > 0; i-- {
+			x := s.Intn(50)
+			d.Push(x)
+			ref = append(ref, x)
+		}
+		for i := 0; i < 50; i++ {
+			x := s.Intn(60) - 5
+			var got, want int
+			switch s.Intn(4) {
+			case 0:
+				got = d.PushPopMin(x)
+				ref = append(ref, x)
+				sort.Ints(ref)
+				want, ref = ref[0], ref[1:]
+			case 1:
+				got = d.PushPopMax(x)
+				ref = append(ref, x)
+				sort.Ints(ref)
+				want, ref = ref[len(ref)-1], ref[:len(ref)-1]
+			case 2:
+				if len(ref) == 0 {
+					continue
+				}
+				got = d.ReplaceMin(x)
+				sort.Ints(ref)
+				want = ref[0]
+				ref[0] = x
+			case 3:
+				if len(ref) == 0 {
+					continue
+				}
+				got = d.ReplaceMax(x)
+				sort.Ints(ref)
+				want = ref[len(ref)-1]
+				ref[len(ref)-1] = x
+			}
+			if got != want {
+				t.Fatalf("unexpected value: %d %d", got, want)
+			}
+			if x, y, ok := isDeheap(t, d.slice()); !ok || d.Len() != len(ref) {
+				t.Fatalf("unexpected value: %d %d %v", x, y, d.data)
+			}
+		}
+	}
+
+}
+
+func benchmarkDeheapPushPop(b *testing.B, pushPop func(d *Deheap[int], x int) int) {
+
+	s := _newRand()
+
+	d := New(intCmp)
+	for i := 0; i < 1<<16; i++ {
+		d.Push(s.Intn(1 << 16))
+	}
+	r := make([]int, b.N)
+	for i := range r {
+		r[i] = s.Intn(1 << 16)
+	}
+
+	b.ResetTimer()
+
+	for _, x := range r {
+		pushPop(d, x)
+	}
+
+}
+
+func BenchmarkDeheapPushPopMin(b *testing.B) { benchmarkDeheapPushPop(b, (*Deheap[int]).PushPopMin) }
+func BenchmarkDeheapPushPopMax(b *testing.B) { benchmarkDeheapPushPop(b, (*Deheap[int]).PushPopMax) }
+
+func BenchmarkDeheapPushThenPopMin(b *testing.B) {
+	benchmarkDeheapPushPop(b, func(d *Deheap[int], x int) int {
+		d.Push(x)
+		return d.PopMin()
+	})
+}
+
+func BenchmarkDeheapPushThenPopMax(b *testing.B) {
+	benchmarkDeheapPushPop(b, func(d *Deheap[int], x int) int {
+		d.Push(x)
+		return d.PopMax()
+	})
+}
+
+// The PushSlice benchmarks push a batch of 1e4 random numbers onto a deheap
+// of 1e5 random numbers, and truncate it back to 1e5 elements, which keeps
+// it ordered.
+
+func benchmarkDeheapPushSlice(b *testing.B, push func(d *Deheap[int], items []int)) {
+	d := New(intCmp)
+	d.PushSlice(randInts(b, 1e5))
+	items := randInts(b, 1e4)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		push(d, items)
+		d.data = d.data[:1e5]
+	}
+}
+
+func BenchmarkDeheapPushSlice(b *testing.B) {
+	benchmarkDeheapPushSlice(b, (*Deheap[int]).PushSlice)
+}
+
+func BenchmarkDeheapPushSliceEach(b *testing.B) {
+	benchmarkDeheapPushSlice(b, func(d *Deheap[int], items []int) {
+		for _, x := range items {
+			d.Push(x)
+		}
+	})
+}
+
+func BenchmarkDeheapPush(b *testing.B) {
+
+	r := []int{}
+	for i := 0; i < b.N; i++ {
+		r = append(r, i)
+	}
+
+	s := _newRand()
+	s.Shuffle(len(r), func(i, j int) { r[i], r[j] = r[j], r[i] })
+
+	b.ResetTimer()
+
+	d := New(intCmp)
+	for _, q := range r {
+		d.Push(q)
+	}
+
+}
+
+func BenchmarkDeheapPopMax(b *testing.B) {
+
+	r := []int{}
+	for i := 0; i < b.N; i++ {
+		r = append(r, i)
+	}
+
+	s := _newRand()
+	s.Shuffle(len(r), func(i, j int) { r[i], r[j] = r[j], r[i] })
+
+	d := New(intCmp)
+	for _, q := range r {
+		d.Push(q)
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		d.PopMax()
+	}
+
+}
+
+// isDeheap is like isHeap but allows duplicate elements
+func isDeheap(t *testing.T, h sort.Interface) (int, int, bool) {
+	t.Helper()
+	l := h.Len()
+	for i := l - 1; i > 0; i-- {
+		min := isMinHeap(i)
+		p0 := parent(i)
+		p1 := hparent(i)
+		if min {
+			if p0 >= 0 && h.Less(i, p0) || h.Less(p1, i) {
+				return p0, i, false
+			}
+		} else {
+			if p0 >= 0 && h.Less(p0, i) || h.Less(i, p1) {
+				return p0, i, false
+			}
+		}
+	}
+	return 0, 0, true
+}
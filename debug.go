@@ -0,0 +1,111 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+//go:build deheapdebug
+
+package deheap
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// debug enables checking the ordering after every operation.  Build with
+// the deheapdebug tag to enable it.
+const debug = true
+
+// debugHistory is the number of operations remembered
+const debugHistory = 256
+
+// debugLog holds the most recent operations on all heaps
+var debugLog struct {
+	sync.Mutex
+	ops [debugHistory]debugOp
+	n   int
+}
+
+type debugOp struct {
+	heap uintptr
+	op   string
+	len  int
+}
+
+// debugf records the operation described by format and args, then panics
+// with the operations recorded for h if h is no longer a valid deheap.
+// Operations on heaps that are not pointers cannot be told apart, and are
+// all recorded together.  The operations on a prefix of a heap are recorded
+// with those on the heap.
+func debugf(h sort.Interface, format string, args ...interface{}) {
+	v := reflect.ValueOf(h)
+	if p, ok := h.(prefix); ok {
+		v = reflect.ValueOf(p.Interface)
+	}
+	l := h.Len()
+	debugRecord(v, h, l, func() error { return verify(h, l) }, format, args)
+}
+
+// debugVerifyf is debugf for heaps with a layout of their own, which are
+// checked by their Verify method.
+func debugVerifyf(h verifier, format string, args ...interface{}) {
+	debugRecord(reflect.ValueOf(h), h, h.Len(), h.Verify, format, args)
+}
+
+// debugRecord records an operation on the heap h, identified by v, and
+// panics with the operations recorded for it if check fails.
+func debugRecord(v reflect.Value, h interface{}, l int, check func() error, format string, args []interface{}) {
+	var id uintptr
+	if v.Kind() == reflect.Pointer {
+		id = v.Pointer()
+	}
+
+	debugLog.Lock()
+	defer debugLog.Unlock()
+	debugLog.ops[debugLog.n%debugHistory] = debugOp{
+		heap: id,
+		op:   fmt.Sprintf(format, args...),
+		len:  l,
+	}
+	debugLog.n++
+
+	err := check()
+	if err == nil {
+		return
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v after %s on %T\n", err, debugLog.ops[(debugLog.n-1)%debugHistory].op, h)
+	b.WriteString("check that Less is a strict weak ordering: irreflexive, transitive, and with transitive equivalence\n")
+	b.WriteString("operations on this heap, oldest first:\n")
+	i := debugLog.n - debugHistory
+	if i < 0 {
+		i = 0
+	}
+	for ; i < debugLog.n; i++ {
+		if op := debugLog.ops[i%debugHistory]; op.heap == id {
+			fmt.Fprintf(&b, "\t%s (len %d)\n", op.op, op.len)
+		}
+	}
+	panic(b.String())
+}
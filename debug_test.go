@@ -0,0 +1,177 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+//go:build deheapdebug
+
+package deheap
+
+import (
+	"strings"
+	"testing"
+)
+
+// flipHeap is an IntHeap whose ordering can be reversed, corrupting it
+type flipHeap struct {
+	IntHeap
+	flip bool
+}
+
+func (h *flipHeap) Less(i, j int) bool {
+	return h.IntHeap.Less(i, j) != h.flip
+}
+
+func TestDebugPanics(t *testing.T) {
+
+	h := &flipHeap{}
+	for i := 0; i < 10; i++ {
+		Push(h, i)
+	}
+	Pop(h)
+
+	defer func() {
+		r := recover()
+		s, ok := r.(string)
+		if !ok {
+			t.Fatalf("unexpected value: %v", r)
+		}
+		if !strings.Contains(s, "after Push(10) on *deheap.flipHeap") ||
+			!strings.Contains(s, "\tPush(9) (len 10)\n\tPop() = 0 (len 9)\n\tPush(10) (len 10)\n") {
+			t.Fatalf("unexpected value: %s", s)
+		}
+	}()
+	h.flip = true
+	Push(h, 10)
+
+}
+
+func TestDebugDeheap(t *testing.T) {
+
+	flip := false
+	d := New(func(a, b int) int {
+		if flip {
+			return intCmp(b, a)
+		}
+		return intCmp(a, b)
+	})
+	for i := 0; i < 10; i++ {
+		d.Push(i)
+	}
+
+	defer func() {
+		if r := recover(); r == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	flip = true
+	d.PopMax()
+
+}
+
+func TestDebugQuadDeheap(t *testing.T) {
+
+	flip := false
+	d := NewQuad(func(a, b int) int {
+		if flip {
+			return intCmp(b, a)
+		}
+		return intCmp(a, b)
+	})
+	for i := 0; i < 30; i++ {
+		d.Push(i)
+	}
+
+	defer func() {
+		r := recover()
+		s, ok := r.(string)
+		if !ok {
+			t.Fatalf("unexpected value: %v", r)
+		}
+		if !strings.Contains(s, "on *deheap.QuadDeheap[int]") ||
+			!strings.Contains(s, "\tPush(29) (len 30)\n\tRemove(0) = 0 (len 29)\n") {
+			t.Fatalf("unexpected value: %s", s)
+		}
+	}()
+	flip = true
+	d.PopMin()
+
+}
+
+func TestDebugIntervalHeap(t *testing.T) {
+
+	flip := false
+	d := NewInterval(func(a, b int) int {
+		if flip {
+			return intCmp(b, a)
+		}
+		return intCmp(a, b)
+	})
+	for i := 0; i < 30; i++ {
+		d.Push(i)
+	}
+	d.PopMax()
+
+	defer func() {
+		r := recover()
+		s, ok := r.(string)
+		if !ok {
+			t.Fatalf("unexpected value: %v", r)
+		}
+		if !strings.Contains(s, "on *deheap.IntervalHeap[int]") ||
+			!strings.Contains(s, "\tPush(29) (len 30)\n\tPopMax() = 29 (len 29)\n\tPopMin() = 0 (len 28)\n") {
+			t.Fatalf("unexpected value: %s", s)
+		}
+	}()
+	flip = true
+	d.PopMin()
+
+}
+
+func TestDebugSymmetricHeap(t *testing.T) {
+
+	flip := false
+	d := NewSymmetric(func(a, b int) int {
+		if flip {
+			return intCmp(b, a)
+		}
+		return intCmp(a, b)
+	})
+	for i := 0; i < 30; i++ {
+		d.Push(i)
+	}
+	d.PopMin()
+
+	defer func() {
+		r := recover()
+		s, ok := r.(string)
+		if !ok {
+			t.Fatalf("unexpected value: %v", r)
+		}
+		if !strings.Contains(s, "on *deheap.SymmetricHeap[int]") ||
+			!strings.Contains(s, "\tPush(29) (len 30)\n\tPopMin() = 0 (len 29)\n\tPopMax() = 29 (len 28)\n") {
+			t.Fatalf("unexpected value: %s", s)
+		}
+	}()
+	flip = true
+	d.PopMax()
+
+}
@@ -29,16 +29,32 @@
 // returns elements from the opposite side of the ordering.
 //
 // This implementation has emphasized compatibility with existing libraries
-// in the sort and heap packages.
+// in the sort and heap packages.  The generic Deheap type offers the same
+// operations without implementing heap.Interface, and PushFunc, PopMinFunc
+// and the related functions offer them on plain slices.
 //
 // Performace of the deheap functions should be very close to the
 // performance of the functions of the heap library
 //
+// Building with the deheapdebug tag checks the ordering after every
+// operation, and panics with the history of the operations on a heap as
+// soon as it is found out of order.
+//
 package deheap
 
 import (
 	"container/heap"
+	"errors"
+	"fmt"
 	"math/bits"
+	"sort"
+)
+
+var (
+	// ErrEmpty is returned when removing an element from an empty heap.
+	ErrEmpty = errors.New("deheap: empty heap")
+	// ErrIndexOutOfRange is returned when an index is not in [0, h.Len()).
+	ErrIndexOutOfRange = errors.New("deheap: index out of range")
 )
 
 func hparent(i int) int {
@@ -65,7 +81,7 @@ func isMinHeap(i int) bool {
 	return level(i) % 2 == 0
 }
 
-func min4(h heap.Interface, l int, min bool, i int)  int {
+func min4(h sort.Interface, l int, min bool, i int) int {
 	q := i
 	i++
 	if i >= l {
@@ -92,7 +108,7 @@ func min4(h heap.Interface, l int, min bool, i int)  int {
 }
 
 // min2
-func min2(h heap.Interface, l int, min bool, i int) int {
+func min2(h sort.Interface, l int, min bool, i int) int {
 	if i+1 >= l {
 		return i
 	}
@@ -103,7 +119,7 @@ func min2(h heap.Interface, l int, min bool, i int) int {
 }
 
 // min3
-func min3(h heap.Interface, l int, min bool, i, j, k int) int {
+func min3(h sort.Interface, l int, min bool, i, j, k int) int {
 	q := i
 	if j < l && h.Less(j, q) == min {
 		q = j
@@ -115,7 +131,7 @@ func min3(h heap.Interface, l int, min bool, i, j, k int) int {
 }
 
 // bubbledown
-func bubbledown(h heap.Interface, l int, min bool, i int) (q int, r int) {
+func bubbledown(h sort.Interface, l int, min bool, i int) (q int, r int) {
 	q = i
 	r = i
 	for {
@@ -147,21 +163,33 @@ func bubbledown(h heap.Interface, l int, min bool, i int) (q int, r int) {
 	return q, r
 }
 
+// less reports whether the element at i belongs above the element at j on
+// the min levels, or on the max levels when min is false
+func less(h sort.Interface, min bool, i, j int) bool {
+	if min {
+		return h.Less(i, j)
+	}
+	return h.Less(j, i)
+}
+
 // bubbleup
-func bubbleup(h heap.Interface, min bool, i int) (q bool) {
+func bubbleup(h sort.Interface, min bool, i int) (q bool) {
 	if i < 0 {
 		return false
 	}
 	j := parent(i)
-	for j >= 0 && min == h.Less(i, j) {
+	for j >= 0 && less(h, min, i, j) {
 		q = true
 		h.Swap(i, j)
 		i = j
 		j = parent(i)
 	}
+	if i == 0 {
+		return q
+	}
 	min = !min
 	j = hparent(i)
-	for j >= 0 && min == h.Less(i, j) {
+	for j >= 0 && less(h, min, i, j) {
 		q = true
 		h.Swap(i, j)
 		i = j
@@ -170,13 +198,45 @@ func bubbleup(h heap.Interface, min bool, i int) (q bool) {
 	return q
 }
 
+// maxIndex returns the index of the largest element of the first l elements
+func maxIndex(h sort.Interface, l int) int {
+	if l > 1 {
+		return min2(h, l, false, 1)
+	}
+	return 0
+}
+
+// fix re-establishes the ordering of the first l elements after the element
+// at index i has changed
+func fix(h sort.Interface, l int, i int) {
+	min := isMinHeap(i)
+	if i > 0 {
+		// an element on the wrong side of its parent moves to the parent's
+		// levels, and the parent's element takes its place and moves down
+		p := hparent(i)
+		if less(h, !min, i, p) {
+			h.Swap(i, p)
+			bubbleup(h, !min, p)
+			bubbledown(h, l, min, i)
+			return
+		}
+	}
+	if !bubbleup(h, min, i) {
+		bubbledown(h, l, min, i)
+	}
+}
+
 // Pop the smallest value off the heap.  See heap.Pop().
 // Time complexity is O(log n), where n = h.Len()
 func Pop(h heap.Interface) interface{} {
 	l := h.Len()-1
+	checkEmpty(l+1, "Pop")
 	h.Swap(0, l)
 	q := h.Pop()
 	bubbledown(h, l, true, 0)
+	if debug {
+		debugf(h, "Pop() = %v", q)
+	}
 	return q
 }
 
@@ -184,14 +244,15 @@ func Pop(h heap.Interface) interface{} {
 // Time complexity is O(log n), where n = h.Len()
 func PopMax(h heap.Interface) interface{} {
 	l := h.Len()
-	j := 0
-	if l > 1 {
-		j = min2(h, l,false, 1)
-	}
+	checkEmpty(l, "PopMax")
+	j := maxIndex(h, l)
 	l = l - 1
 	h.Swap(j, l)
 	q := h.Pop()
 	bubbledown(h, l,false, j)
+	if debug {
+		debugf(h, "PopMax() = %v", q)
+	}
 	return q
 }
 
@@ -199,12 +260,212 @@ func PopMax(h heap.Interface) interface{} {
 // The complexity is O(log n) where n = h.Len().
 func Remove(h heap.Interface, i int) (q interface{}) {
 	l := h.Len() - 1
+	checkIndex(i, l+1, "Remove")
 	h.Swap(i, l)
 	q = h.Pop()
 	if l != i {
-		q, r := bubbledown(h, l, isMinHeap(i), i)
-		bubbleup(h, isMinHeap(q), q)
-		bubbleup(h, isMinHeap(r), r)
+		fix(h, l, i)
+	}
+	if debug {
+		debugf(h, "Remove(%d) = %v", i, q)
+	}
+	return q
+}
+
+// TryPop pops the smallest value off the heap, or returns ErrEmpty if the
+// heap is empty.  See Pop().
+func TryPop(h heap.Interface) (interface{}, error) {
+	if h.Len() == 0 {
+		return nil, ErrEmpty
+	}
+	return Pop(h), nil
+}
+
+// TryPopMax pops the largest value off the heap, or returns ErrEmpty if the
+// heap is empty.  See PopMax().
+func TryPopMax(h heap.Interface) (interface{}, error) {
+	if h.Len() == 0 {
+		return nil, ErrEmpty
+	}
+	return PopMax(h), nil
+}
+
+// TryRemove removes the element at index i, or returns ErrIndexOutOfRange
+// if i is not in [0, h.Len()).  See Remove().
+func TryRemove(h heap.Interface, i int) (interface{}, error) {
+	if i < 0 || i >= h.Len() {
+		return nil, ErrIndexOutOfRange
+	}
+	return Remove(h, i), nil
+}
+
+// Fix re-establishes the heap ordering after the element at index i has
+// changed its value.  Changing the value of the element at index i and then
+// calling Fix is equivalent to, but less expensive than, calling Remove(h, i)
+// followed by a Push of the new value.  See heap.Fix()
+// Time complexity is O(log n), where n = h.Len()
+func Fix(h heap.Interface, i int) {
+	l := h.Len()
+	checkIndex(i, l, "Fix")
+	fix(h, l, i)
+	if debug {
+		debugf(h, "Fix(%d)", i)
+	}
+}
+
+// Up moves the element at index i up to its place in the heap.  Appending an
+// element to a slice ordered as a heap and calling Up with its index pushes
+// it without going through heap.Interface, so that no value is boxed in an
+// interface.  See Push().
+// Time complexity is O(log n), where n = h.Len()
+func Up(h sort.Interface, i int) {
+	checkIndex(i, h.Len(), "Up")
+	bubbleup(h, isMinHeap(i), i)
+	if debug {
+		debugf(h, "Up(%d)", i)
+	}
+}
+
+// Down re-establishes the heap ordering after the element at index i has
+// changed its value, moving it down the heap or, when it is out of order
+// with its ancestors, up.  It is Fix for values that only implement
+// sort.Interface.  See Fix().
+// Time complexity is O(log n), where n = h.Len()
+func Down(h sort.Interface, i int) {
+	l := h.Len()
+	checkIndex(i, l, "Down")
+	fix(h, l, i)
+	if debug {
+		debugf(h, "Down(%d)", i)
+	}
+}
+
+// PrepareRemoveMin moves the smallest element to the end of the heap and
+// re-establishes the ordering of the elements before it, returning its
+// index, h.Len()-1.  The caller takes the element at that index and removes
+// it by truncating its slice.  See Pop().
+// Time complexity is O(log n), where n = h.Len()
+func PrepareRemoveMin(h sort.Interface) int {
+	l := h.Len() - 1
+	checkEmpty(l+1, "PrepareRemoveMin")
+	h.Swap(0, l)
+	bubbledown(h, l, true, 0)
+	if debug {
+		debugf(prefix{h, l}, "PrepareRemoveMin() = %d", l)
+	}
+	return l
+}
+
+// PrepareRemoveMax moves the largest element to the end of the heap.  See
+// PrepareRemoveMin() and PopMax().
+// Time complexity is O(log n), where n = h.Len()
+func PrepareRemoveMax(h sort.Interface) int {
+	l := h.Len()
+	checkEmpty(l, "PrepareRemoveMax")
+	j := maxIndex(h, l)
+	l = l - 1
+	h.Swap(j, l)
+	bubbledown(h, l, false, j)
+	if debug {
+		debugf(prefix{h, l}, "PrepareRemoveMax() = %d", l)
+	}
+	return l
+}
+
+// prefix is the first n elements of a heap, the part left ordered by
+// PrepareRemoveMin and PrepareRemoveMax
+type prefix struct {
+	sort.Interface
+	n int
+}
+
+func (p prefix) Len() int { return p.n }
+
+// Meld moves all the elements of src onto dst, leaving src empty.  The
+// elements are either pushed one at a time or appended to dst before
+// rebuilding it, depending on their number relative to the size of dst.
+// Melding a heap with itself leaves it unchanged.
+// Time complexity is O(min(m log(n+m), n+m)), where n = dst.Len() and m = src.Len()
+func Meld(dst, src heap.Interface) {
+	n := dst.Len()
+	for m := src.Len(); m > 0; m-- {
+		dst.Push(src.Pop())
+	}
+	appended(dst, n, dst.Len())
+	if debug {
+		debugf(dst, "Meld(%d elements)", dst.Len()-n)
+	}
+}
+
+// PushPopMin pushes o onto the heap and pops the smallest value off it.
+// When o is no greater than the smallest value the heap is left unchanged
+// and o is returned; otherwise o takes the place of the smallest value with
+// a single pass down the heap, which is faster than Push followed by Pop.
+// Time complexity is O(log n), where n = h.Len()
+func PushPopMin(h heap.Interface, o interface{}) interface{} {
+	h.Push(o)
+	l := h.Len() - 1
+	if l == 0 || !h.Less(0, l) {
+		return h.Pop()
+	}
+	h.Swap(0, l)
+	q := h.Pop()
+	bubbledown(h, l, true, 0)
+	if debug {
+		debugf(h, "PushPopMin(%v) = %v", o, q)
+	}
+	return q
+}
+
+// PushPopMax pushes o onto the heap and pops the largest value off it.
+// See PushPopMin().
+// Time complexity is O(log n), where n = h.Len()
+func PushPopMax(h heap.Interface, o interface{}) interface{} {
+	h.Push(o)
+	l := h.Len() - 1
+	j := maxIndex(h, l)
+	if l == 0 || !h.Less(l, j) {
+		return h.Pop()
+	}
+	h.Swap(j, l)
+	q := h.Pop()
+	fix(h, l, j)
+	if debug {
+		debugf(h, "PushPopMax(%v) = %v", o, q)
+	}
+	return q
+}
+
+// ReplaceMin pops the smallest value off the heap and pushes o onto it, in
+// a single pass down the heap.  Unlike PushPopMin, the value returned is
+// never o.
+// Time complexity is O(log n), where n = h.Len()
+func ReplaceMin(h heap.Interface, o interface{}) interface{} {
+	checkEmpty(h.Len(), "ReplaceMin")
+	h.Push(o)
+	l := h.Len() - 1
+	h.Swap(0, l)
+	q := h.Pop()
+	bubbledown(h, l, true, 0)
+	if debug {
+		debugf(h, "ReplaceMin(%v) = %v", o, q)
+	}
+	return q
+}
+
+// ReplaceMax pops the largest value off the heap and pushes o onto it.
+// See ReplaceMin().
+// Time complexity is O(log n), where n = h.Len()
+func ReplaceMax(h heap.Interface, o interface{}) interface{} {
+	checkEmpty(h.Len(), "ReplaceMax")
+	h.Push(o)
+	l := h.Len() - 1
+	j := maxIndex(h, l)
+	h.Swap(j, l)
+	q := h.Pop()
+	fix(h, l, j)
+	if debug {
+		debugf(h, "ReplaceMax(%v) = %v", o, q)
 	}
 	return q
 }
@@ -216,13 +477,90 @@ func Push(h heap.Interface, o interface{}) {
 	l := h.Len()
 	i := l - 1
 	bubbleup(h, isMinHeap(i), i)
+	if debug {
+		debugf(h, "Push(%v)", o)
+	}
+}
+
+// PushAll pushes all of items onto the heap.  The items are appended with
+// h.Push and then either bubbled up one at a time or the whole heap is
+// rebuilt, depending on their number relative to the size of the heap.
+// Time complexity is O(min(k log(n+k), n+k)), where n = h.Len() and k = len(items)
+func PushAll(h heap.Interface, items ...interface{}) {
+	n := h.Len()
+	for _, o := range items {
+		h.Push(o)
+	}
+	appended(h, n, h.Len())
+	if debug {
+		debugf(h, "PushAll(%d elements)", len(items))
+	}
 }
 
 // Init initializes the heap.
 // This should be called once on non-empty heaps before calling Pop(), PopMax() or Push().  See heap.Init()
+// Time complexity is O(n), where n = h.Len()
 func Init(h heap.Interface) {
-	l := h.Len()
-	for i := 0; i < l; i++ {
+	heapify(h, h.Len())
+	if debug {
+		debugf(h, "Init()")
+	}
+}
+
+// appended re-establishes the ordering of the first l elements after the
+// elements from index n on have been added, bubbling each added element up
+// or rebuilding the whole heap, whichever takes fewer steps.  See rebuild().
+func appended(h sort.Interface, n int, l int) {
+	if rebuild(n, l) {
+		heapify(h, l)
+		return
+	}
+	for i := n; i < l; i++ {
 		bubbleup(h, isMinHeap(i), i)
 	}
 }
+
+// rebuild reports whether a heap of l elements, of which those from index n
+// on have just been added, is rebuilt rather than bubbling each added element
+// up.  Bubbling up compares an element with at most one ancestor per two
+// levels, but usually stops after two or three comparisons whatever the size
+// of the heap, while rebuilding makes two to five comparisons per element of
+// the whole heap.  Both costs grow linearly, so the cutoff is a constant ratio:
+// the heap is only rebuilt when the added elements outnumber those already
+// there, which bounds the cost of adding elements that all bubble up to the
+// root, such as decreasing ones.
+func rebuild(n int, l int) bool {
+	return l-n > n
+}
+
+// heapify builds a heap of the first l elements from the bottom up, moving
+// each element down its subtree from the last internal node to the root
+func heapify(h sort.Interface, l int) {
+	for i := l/2 - 1; i >= 0; i-- {
+		bubbledown(h, l, isMinHeap(i), i)
+	}
+}
+
+// checkEmpty panics when removing an element from an empty heap, before the
+// heap is changed
+func checkEmpty(l int, op string) {
+	if l == 0 {
+		panic(fmt.Errorf("%w in %s", ErrEmpty, op))
+	}
+}
+
+// checkRank panics when k is not the rank of one of the l elements of a
+// heap, counting from 1
+func checkRank(k int, l int, op string) {
+	if k < 1 || k > l {
+		panic(fmt.Errorf("%w: %s rank %d with length %d", ErrIndexOutOfRange, op, k, l))
+	}
+}
+
+// checkIndex panics when i is not the index of one of the l elements of a
+// heap, before the heap is changed
+func checkIndex(i int, l int, op string) {
+	if i < 0 || i >= l {
+		panic(fmt.Errorf("%w: %s index %d with length %d", ErrIndexOutOfRange, op, i, l))
+	}
+}
@@ -24,6 +24,7 @@ package deheap
 
 import (
 	"container/heap"
+	"errors"
 	"fmt"
 	"math"
 	"math/rand"
@@ -360,6 +361,24 @@ func TestInit(t *testing.T) {
 	}
 }
 
+func TestInitRandom(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 1000; k++ {
+		N := s.Intn(200)
+		h := &IntHeap{}
+		for i := 0; i < N; i++ {
+			*h = append(*h, s.Intn(N/2+1))
+		}
+		Init(h)
+		if x, y, ok := isDeheap(t, h); !ok {
+			t.Fatalf("unexpected value: %d %d %v", x, y, h)
+		}
+	}
+
+}
+
 func TestPush(t *testing.T) {
 
 	h := &IntHeap{}
@@ -655,6 +674,356 @@ func TestRemove(t *testing.T) {
 
 }
 
+// TestRemoveRandom removes elements from random positions of deep heaps.
+// Moving the last element into the place of the removed one can put it out
+// of order with the ancestors of that place, which only a few removals on
+// heaps of hundreds of elements reach.
+func TestRemoveRandom(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 1000; k++ {
+		h := &IntHeap{}
+		ref := []int{}
+		for i := s.Intn(2000) + 1; i > 0; i-- {
+			x := s.Intn(1000)
+			Push(h, x)
+			ref = append(ref, x)
+		}
+		sort.Ints(ref)
+		for h.Len() > 0 {
+			x := Remove(h, s.Intn(h.Len())).(int)
+			j := sort.SearchInts(ref, x)
+			if j == len(ref) || ref[j] != x {
+				t.Fatalf("unexpected value: %d", x)
+			}
+			ref = append(ref[:j], ref[j+1:]...)
+			if h.Len()%32 != 0 {
+				continue
+			}
+			if x, y, ok := isDeheap(t, h); !ok {
+				t.Fatalf("unexpected value: %d %d %d", k, x, y)
+			}
+			if h.Len() > 0 && ((*h)[0] != ref[0] || (*h)[maxIndex(h, h.Len())] != ref[len(ref)-1]) {
+				t.Fatalf("unexpected value: %d %d", (*h)[0], ref[0])
+			}
+		}
+	}
+
+}
+
+func TestFix(t *testing.T) {
+
+	h := &IntHeap{0, 9, 5, 6, 1, 2, 4, 8, 7, 3}
+
+	(*h)[0] = 10
+	Fix(h, 0)
+	if _, _, ok := isHeap(t, h); !ok {
+		t.Fatalf("unexpected value: %v", h)
+	}
+	if x := PopMax(h).(int); x != 10 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+
+	(*h)[1] = -1
+	Fix(h, 1)
+	if _, _, ok := isHeap(t, h); !ok {
+		t.Fatalf("unexpected value: %v", h)
+	}
+	if x := Pop(h).(int); x != -1 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+
+	s := _newRand()
+
+	for k := 0; k < 1000; k++ {
+		N := s.Intn(64) + 1
+		h = randIntHeap(t, N)
+		for j := 0; j < N; j++ {
+			i := s.Intn(h.Len())
+			(*h)[i] = s.Intn(N) * 2
+			Fix(h, i)
+			if x, y, ok := isDeheap(t, h); !ok {
+				t.Fatalf("unexpected value: %d %d %v", x, y, h)
+			}
+		}
+		x0 := Pop(h).(int)
+		for h.Len() > 0 {
+			x := Pop(h).(int)
+			if x < x0 {
+				t.Fatalf("unexpected value: %d %d", x0, x)
+			}
+			x0 = x
+		}
+	}
+
+}
+
+func TestMeld(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 500; k++ {
+		N := s.Intn(100)
+		M := s.Intn(100)
+		if k%2 == 0 {
+			M = s.Intn(4)
+		}
+		h0 := &IntHeap{}
+		for i := 0; i < N; i++ {
+			Push(h0, s.Intn(50))
+		}
+		h1 := &IntHeap{}
+		for i := 0; i < M; i++ {
+			Push(h1, s.Intn(50))
+		}
+		ref := append(append([]int{}, *h0...), *h1...)
+		sort.Ints(ref)
+		Meld(h0, h1)
+		if h1.Len() != 0 {
+			t.Fatalf("unexpected value: %d", h1.Len())
+		}
+		if x, y, ok := isDeheap(t, h0); !ok {
+			t.Fatalf("unexpected value: %d %d %v", x, y, h0)
+		}
+		for _, y := range ref {
+			if x := Pop(h0).(int); x != y {
+				t.Fatalf("unexpected value: %d %d", x, y)
+			}
+		}
+	}
+
+	h := &IntHeap{}
+	for i := 0; i < 10; i++ {
+		Push(h, i)
+	}
+	before := append(IntHeap{}, *h...)
+	Meld(h, h)
+	if !reflect.DeepEqual(*h, before) {
+		t.Fatalf("unexpected value: %v %v", *h, before)
+	}
+
+}
+
+func TestPushAll(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 500; k++ {
+		// large batches onto small heaps are pushed by rebuilding the heap
+		N := s.Intn(100)
+		M := s.Intn(100)
+		if k%2 == 0 {
+			N = s.Intn(4)
+			M = 1000 + s.Intn(1000)
+		}
+		h := &IntHeap{}
+		for i := 0; i < N; i++ {
+			Push(h, s.Intn(50))
+		}
+		items := make([]interface{}, M)
+		for i := range items {
+			items[i] = s.Intn(50)
+		}
+		ref := append([]int{}, *h...)
+		for _, x := range items {
+			ref = append(ref, x.(int))
+		}
+		sort.Ints(ref)
+		PushAll(h, items...)
+		if x, y, ok := isDeheap(t, h); !ok {
+			t.Fatalf("unexpected value: %d %d %v", x, y, h)
+		}
+		for _, y := range ref {
+			if x := Pop(h).(int); x != y {
+				t.Fatalf("unexpected value: %d %d", x, y)
+			}
+		}
+	}
+
+}
+
+func TestUpDown(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		h := &IntHeap{}
+		ref := []int{}
+		for i := 0; i < 200; i++ {
+			switch op := s.Intn(5); {
+			case op < 2 || len(ref) == 0:
+				x := s.Intn(100)
+				*h = append(*h, x)
+				Up(h, h.Len()-1)
+				ref = append(ref, x)
+			case op == 2:
+				j := PrepareRemoveMin(h)
+				if x := (*h)[j]; x != ref[0] || j != h.Len()-1 {
+					t.Fatalf("unexpected value: %d %d %d", j, x, ref[0])
+				}
+				*h = (*h)[:j]
+				ref = ref[1:]
+			case op == 3:
+				j := PrepareRemoveMax(h)
+				if x := (*h)[j]; x != ref[len(ref)-1] || j != h.Len()-1 {
+					t.Fatalf("unexpected value: %d %d %d", j, x, ref[len(ref)-1])
+				}
+				*h = (*h)[:j]
+				ref = ref[:len(ref)-1]
+			default:
+				j := s.Intn(h.Len())
+				k := sort.SearchInts(ref, (*h)[j])
+				x := s.Intn(100)
+				(*h)[j] = x
+				Down(h, j)
+				ref[k] = x
+			}
+			sort.Ints(ref)
+			if x, y, ok := isDeheap(t, h); !ok {
+				t.Fatalf("unexpected value: %d %d %v", x, y, h)
+			}
+		}
+	}
+
+	// the deheapdebug checks allocate
+	if debug {
+		return
+	}
+	h := make(IntHeap, 0, 100)
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 100; i++ {
+			h = append(h, i*7%100)
+			Up(&h, len(h)-1)
+		}
+		for len(h) > 0 {
+			h = h[:PrepareRemoveMax(&h)]
+		}
+	})
+	if allocs != 0 {
+		t.Fatalf("unexpected value: %v", allocs)
+	}
+
+}
+
+func TestPushPopReplace(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 500; k++ {
+		h := &IntHeap{}
+		ref := []int{}
+		for i := s.Intn(50); i > 0; i-- {
+			x := s.Intn(50)
+			Push(h, x)
+			ref = append(ref, x)
+		}
+		for i := 0; i < 50; i++ {
+			x := s.Intn(60) - 5
+			var got, want int
+			switch s.Intn(4) {
+			case 0:
+				got = PushPopMin(h, x).(int)
+				ref = append(ref, x)
+				sort.Ints(ref)
+				want, ref = ref[0], ref[1:]
+			case 1:
+				got = PushPopMax(h, x).(int)
+				ref = append(ref, x)
+				sort.Ints(ref)
+				want, ref = ref[len(ref)-1], ref[:len(ref)-1]
+			case 2:
+				if len(ref) == 0 {
+					continue
+				}
+				got = ReplaceMin(h, x).(int)
+				sort.Ints(ref)
+				want = ref[0]
+				ref[0] = x
+			case 3:
+				if len(ref) == 0 {
+					continue
+				}
+				got = ReplaceMax(h, x).(int)
+				sort.Ints(ref)
+				want = ref[len(ref)-1]
+				ref[len(ref)-1] = x
+			}
+			if got != want {
+				t.Fatalf("unexpected value: %d %d", got, want)
+			}
+			if x, y, ok := isDeheap(t, h); !ok || h.Len() != len(ref) {
+				t.Fatalf("unexpected value: %d %d %v", x, y, h)
+			}
+		}
+	}
+
+}
+
+func TestTryPop(t *testing.T) {
+
+	h := &IntHeap{1, 3, 2}
+	if x, err := TryPopMax(h); x != 3 || err != nil {
+		t.Fatalf("unexpected value: %v %v", x, err)
+	}
+	if x, err := TryRemove(h, 1); x != 2 || err != nil {
+		t.Fatalf("unexpected value: %v %v", x, err)
+	}
+	if _, err := TryRemove(h, 1); err != ErrIndexOutOfRange {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if _, err := TryRemove(h, -1); err != ErrIndexOutOfRange {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if x, err := TryPop(h); x != 1 || err != nil {
+		t.Fatalf("unexpected value: %v %v", x, err)
+	}
+	if _, err := TryPop(h); err != ErrEmpty {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if _, err := TryPopMax(h); err != ErrEmpty {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+}
+
+func TestPanics(t *testing.T) {
+
+	expectPanic := func(target error, msg string, f func()) {
+		t.Helper()
+		defer func() {
+			t.Helper()
+			err, ok := recover().(error)
+			if !ok || !errors.Is(err, target) || err.Error() != msg {
+				t.Fatalf("unexpected value: %v", err)
+			}
+		}()
+		f()
+	}
+
+	h := &IntHeap{}
+	expectPanic(ErrEmpty, "deheap: empty heap in Pop", func() { Pop(h) })
+	expectPanic(ErrEmpty, "deheap: empty heap in PopMax", func() { PopMax(h) })
+	expectPanic(ErrEmpty, "deheap: empty heap in ReplaceMin", func() { ReplaceMin(h, 1) })
+	expectPanic(ErrEmpty, "deheap: empty heap in ReplaceMax", func() { ReplaceMax(h, 1) })
+	if h.Len() != 0 {
+		t.Fatalf("unexpected value: %v", h)
+	}
+
+	h = &IntHeap{1, 3, 2}
+	expectPanic(ErrIndexOutOfRange, "deheap: index out of range: Remove index 3 with length 3", func() { Remove(h, 3) })
+	expectPanic(ErrIndexOutOfRange, "deheap: index out of range: Fix index -1 with length 3", func() { Fix(h, -1) })
+	if !reflect.DeepEqual(h, &IntHeap{1, 3, 2}) {
+		t.Fatalf("unexpected value: %v", h)
+	}
+
+	d := New(intCmp)
+	expectPanic(ErrEmpty, "deheap: empty heap in PopMin", func() { d.PopMin() })
+	expectPanic(ErrEmpty, "deheap: empty heap in PeekMax", func() { d.PeekMax() })
+	expectPanic(ErrIndexOutOfRange, "deheap: index out of range: Remove index 0 with length 0", func() { d.Remove(0) })
+
+}
+
 func TestDups(t *testing.T) {
 
 	h := &IntHeap{}
@@ -809,6 +1178,26 @@ func BenchmarkPush(b *testing.B) {
 
 }
 
+func BenchmarkUp(b *testing.B) {
+
+	r := []int{}
+	for i := 0; i < b.N; i++ {
+		r = append(r, i)
+	}
+
+	s := _newRand()
+	s.Shuffle(len(r), func(i, j int) { r[i], r[j] = r[j], r[i] })
+
+	b.ResetTimer()
+
+	h := &IntHeap{}
+	for _, q := range r {
+		*h = append(*h, q)
+		Up(h, len(*h)-1)
+	}
+
+}
+
 func BenchmarkPop(b *testing.B) {
 
 	r := []int{}
@@ -926,6 +1315,65 @@ func BenchmarkHeapPop(b *testing.B) {
 
 }
 
+func BenchmarkFix(b *testing.B) {
+
+	s := _newRand()
+
+	h := &IntHeap{}
+	for i := 0; i < 1<<16; i++ {
+		Push(h, s.Intn(1<<16))
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		j := s.Intn(h.Len())
+		(*h)[j] = s.Intn(1 << 16)
+		Fix(h, j)
+	}
+
+}
+
+func benchmarkPushPop(b *testing.B, pushPop func(h heap.Interface, x interface{}) interface{}) {
+
+	s := _newRand()
+
+	h := &IntHeap{}
+	for i := 0; i < 1<<16; i++ {
+		Push(h, s.Intn(1<<16))
+	}
+	r := make([]int, b.N)
+	for i := range r {
+		r[i] = s.Intn(1 << 16)
+	}
+
+	b.ResetTimer()
+
+	for _, x := range r {
+		pushPop(h, x)
+	}
+
+}
+
+func BenchmarkPushPopMin(b *testing.B) { benchmarkPushPop(b, PushPopMin) }
+func BenchmarkPushPopMax(b *testing.B) { benchmarkPushPop(b, PushPopMax) }
+func BenchmarkReplaceMin(b *testing.B) { benchmarkPushPop(b, ReplaceMin) }
+func BenchmarkReplaceMax(b *testing.B) { benchmarkPushPop(b, ReplaceMax) }
+
+func BenchmarkPushThenPop(b *testing.B) {
+	benchmarkPushPop(b, func(h heap.Interface, x interface{}) interface{} {
+		Push(h, x)
+		return Pop(h)
+	})
+}
+
+func BenchmarkPushThenPopMax(b *testing.B) {
+	benchmarkPushPop(b, func(h heap.Interface, x interface{}) interface{} {
+		Push(h, x)
+		return PopMax(h)
+	})
+}
+
 func BenchmarkHeapPush(b *testing.B) {
 
 	r := []int{}
@@ -945,7 +1393,42 @@ func BenchmarkHeapPush(b *testing.B) {
 
 }
 
-func isHeap(t *testing.T, h heap.Interface) (int, int, bool) {
+// initByPush is the O(n log n) Init that bubbles up every element in turn
+func initByPush(h heap.Interface) {
+	l := h.Len()
+	for i := 0; i < l; i++ {
+		bubbleup(h, isMinHeap(i), i)
+	}
+}
+
+func benchmarkInit(b *testing.B, n int, init func(h heap.Interface)) {
+
+	s := _newRand()
+	r := make(IntHeap, n)
+	for i := range r {
+		r[i] = s.Int()
+	}
+	h := make(IntHeap, n)
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		b.StopTimer()
+		copy(h, r)
+		b.StartTimer()
+		init(&h)
+	}
+
+}
+
+func BenchmarkInit1e3(b *testing.B) { benchmarkInit(b, 1e3, Init) }
+func BenchmarkInit1e6(b *testing.B) { benchmarkInit(b, 1e6, Init) }
+func BenchmarkInitByPush1e3(b *testing.B) { benchmarkInit(b, 1e3, initByPush) }
+func BenchmarkInitByPush1e6(b *testing.B) { benchmarkInit(b, 1e6, initByPush) }
+func BenchmarkHeapInit1e3(b *testing.B) { benchmarkInit(b, 1e3, heap.Init) }
+func BenchmarkHeapInit1e6(b *testing.B) { benchmarkInit(b, 1e6, heap.Init) }
+
+func isHeap(t *testing.T, h sort.Interface) (int, int, bool) {
 	t.Helper()
 	l := h.Len()
 	for i := l - 1; i >= 0; i-- {
@@ -0,0 +1,371 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+// Package durable provides a doubly ended priority queue that survives
+// process restarts.
+//
+// A Queue keeps its elements in a deheap.Deheap in memory, and appends every
+// change to a write-ahead log in its directory before making it.  The log is
+// compacted from time to time into a snapshot of the deheap, and Open
+// rebuilds the queue from the latest snapshot and the log written after it.
+// Elements are encoded with encoding/gob.
+//
+// Every log record carries its length and a checksum.  A record that was
+// only partly written when the process or the machine stopped is found when
+// the log is replayed, and the log is truncated to the records before it.
+// A bad record followed by more of the log is not a torn write, and Open
+// returns an error instead of dropping the records after it.
+package durable
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+
+	"github.com/aalpar/deheap"
+)
+
+// SyncPolicy selects when a Queue syncs its log to stable storage.
+type SyncPolicy int
+
+const (
+	// SyncEveryOp syncs the log after every operation, so that an
+	// operation that has returned survives a crash of the machine.
+	SyncEveryOp SyncPolicy = iota
+	// SyncBatched syncs the log after every Options.BatchSize operations.
+	// A crash of the machine may lose the operations since the last sync.
+	SyncBatched
+	// SyncNone leaves syncing the log to the operating system.
+	SyncNone
+)
+
+// Options configure a Queue.  The zero value syncs after every operation
+// and compacts the log with the default threshold.
+type Options struct {
+	// Sync is when the log is synced.  Sync, Compact and Close always
+	// sync, whatever the policy.
+	Sync SyncPolicy
+	// BatchSize is the number of operations between syncs with
+	// SyncBatched.  Zero means 64.
+	BatchSize int
+	// CompactAfter is the number of log records after which the log is
+	// compacted, once it also has at least as many records as the queue
+	// has elements, which keeps the cost of compaction proportional to the
+	// number of operations.  Zero means 1024, and a negative number turns
+	// automatic compaction off.
+	CompactAfter int
+}
+
+const (
+	defaultBatchSize    = 64
+	defaultCompactAfter = 1024
+)
+
+// Queue is a doubly ended priority queue whose changes are logged to files
+// in a directory.  It is safe for concurrent use by multiple goroutines, but
+// only one Queue may use a directory at a time.
+//
+// The operations that change the queue write the change to the log before
+// making it, and return an error if the log cannot be written, leaving the
+// queue unchanged.  After a failure that leaves the log in an unknown state,
+// such as a failed sync, every operation returns that error until the queue
+// is closed and opened again, and the failed operation may or may not be
+// found in the log.
+type Queue[T any] struct {
+	mu   sync.Mutex
+	dir  string
+	opts Options
+	d    *deheap.Deheap[T]
+	cmp  func(a, b T) int
+	// log is the open log of generation gen, size bytes long with records
+	// records, unsynced of which have not been synced
+	log      *os.File
+	gen      uint64
+	size     int64
+	records  int
+	unsynced int
+	// compactAt is the number of records at which to compact next
+	compactAt int
+	err       error
+	closed    bool
+}
+
+// Open opens the queue in dir, ordered by cmp, creating dir if needed.  The
+// snapshot and the log in dir are replayed to rebuild the queue, and a torn
+// record at the end of the log is truncated.  Any other damage to the
+// snapshot or the log returns an error wrapping deheap.ErrCorrupt.  cmp must order the elements
+// the same way every time the queue is opened.  See deheap.New().
+func Open[T any](dir string, cmp func(a, b T) int, opts Options) (*Queue[T], error) {
+	if opts.BatchSize <= 0 {
+		opts.BatchSize = defaultBatchSize
+	}
+	if opts.CompactAfter == 0 {
+		opts.CompactAfter = defaultCompactAfter
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	// temporary files are left behind by compactions that did not finish
+	for _, name := range []string{snapshotName, logName} {
+		if err := os.Remove(filepath.Join(dir, name+tmpSuffix)); err != nil && !errors.Is(err, os.ErrNotExist) {
+			return nil, err
+		}
+	}
+	q := &Queue[T]{dir: dir, opts: opts, d: deheap.New(cmp), cmp: cmp}
+	if err := q.readSnapshot(); err != nil {
+		return nil, err
+	}
+	if err := q.openLog(); err != nil {
+		return nil, err
+	}
+	q.compactAt = q.records + opts.CompactAfter
+	return q, nil
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.d.Len()
+}
+
+// At returns the element at index i of the underlying deheap.  See
+// deheap.Deheap.At().
+func (q *Queue[T]) At(i int) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.check(); err != nil {
+		return zero, err
+	}
+	if i < 0 || i >= q.d.Len() {
+		return zero, fmt.Errorf("%w: At index %d with length %d", deheap.ErrIndexOutOfRange, i, q.d.Len())
+	}
+	return q.d.At(i), nil
+}
+
+// PeekMin returns the smallest element without removing it, or
+// deheap.ErrEmpty if the queue is empty.
+func (q *Queue[T]) PeekMin() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.checkEmpty(); err != nil {
+		return zero, err
+	}
+	return q.d.PeekMin(), nil
+}
+
+// PeekMax returns the largest element without removing it, or
+// deheap.ErrEmpty if the queue is empty.
+func (q *Queue[T]) PeekMax() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.checkEmpty(); err != nil {
+		return zero, err
+	}
+	return q.d.PeekMax(), nil
+}
+
+// Push adds x to the queue.
+func (q *Queue[T]) Push(x T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.check(); err != nil {
+		return err
+	}
+	p, err := encodeElement(x)
+	if err != nil {
+		return err
+	}
+	if err := q.append(opPush, p); err != nil {
+		return err
+	}
+	q.d.Push(x)
+	q.compact()
+	return nil
+}
+
+// PopMin removes and returns the smallest element, or returns
+// deheap.ErrEmpty if the queue is empty.
+func (q *Queue[T]) PopMin() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.checkEmpty(); err != nil {
+		return zero, err
+	}
+	if err := q.append(opPopMin, nil); err != nil {
+		return zero, err
+	}
+	x := q.d.PopMin()
+	q.compact()
+	return x, nil
+}
+
+// PopMax removes and returns the largest element, or returns
+// deheap.ErrEmpty if the queue is empty.
+func (q *Queue[T]) PopMax() (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.checkEmpty(); err != nil {
+		return zero, err
+	}
+	if err := q.append(opPopMax, nil); err != nil {
+		return zero, err
+	}
+	x := q.d.PopMax()
+	q.compact()
+	return x, nil
+}
+
+// Remove removes and returns the element at index i of the underlying
+// deheap, or returns an error wrapping deheap.ErrIndexOutOfRange if i is
+// not in [0, q.Len()).  See deheap.Deheap.Remove().
+func (q *Queue[T]) Remove(i int) (T, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	var zero T
+	if err := q.check(); err != nil {
+		return zero, err
+	}
+	if i < 0 || i >= q.d.Len() {
+		return zero, fmt.Errorf("%w: Remove index %d with length %d", deheap.ErrIndexOutOfRange, i, q.d.Len())
+	}
+	if err := q.append(opRemove, encodeIndex(i)); err != nil {
+		return zero, err
+	}
+	x := q.d.Remove(i)
+	q.compact()
+	return x, nil
+}
+
+// Sync syncs the log to stable storage.
+func (q *Queue[T]) Sync() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.check(); err != nil {
+		return err
+	}
+	return q.sync()
+}
+
+// Compact writes a snapshot of the queue and starts a new, empty log.
+func (q *Queue[T]) Compact() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.check(); err != nil {
+		return err
+	}
+	return q.writeSnapshot()
+}
+
+// Close syncs the log and closes the queue.  Operations on a closed queue
+// return deheap.ErrClosed.
+func (q *Queue[T]) Close() error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.closed {
+		return deheap.ErrClosed
+	}
+	q.closed = true
+	err := q.err
+	if err == nil {
+		err = q.log.Sync()
+	}
+	if cerr := q.log.Close(); err == nil {
+		err = cerr
+	}
+	return err
+}
+
+// check returns the error that operations on q should fail with, if any
+func (q *Queue[T]) check() error {
+	if q.closed {
+		return deheap.ErrClosed
+	}
+	return q.err
+}
+
+func (q *Queue[T]) checkEmpty() error {
+	if err := q.check(); err != nil {
+		return err
+	}
+	if q.d.Len() == 0 {
+		return deheap.ErrEmpty
+	}
+	return nil
+}
+
+// append writes a record to the log and syncs it as the policy requires.
+// A record that cannot be written is truncated from the log.
+func (q *Queue[T]) append(op byte, payload []byte) error {
+	r := encodeRecord(op, payload)
+	if _, err := q.log.Write(r); err != nil {
+		if terr := q.log.Truncate(q.size); terr != nil {
+			q.err = err
+		}
+		return err
+	}
+	q.size += int64(len(r))
+	q.records++
+	q.unsynced++
+	switch q.opts.Sync {
+	case SyncEveryOp:
+		return q.sync()
+	case SyncBatched:
+		if q.unsynced >= q.opts.BatchSize {
+			return q.sync()
+		}
+	}
+	return nil
+}
+
+// sync syncs the log.  A failed sync leaves the written records in an
+// unknown state, so it stops all further operations.
+func (q *Queue[T]) sync() error {
+	if err := q.log.Sync(); err != nil {
+		q.err = err
+		return err
+	}
+	q.unsynced = 0
+	return nil
+}
+
+// compact writes a snapshot once the log is long enough.  An operation has
+// been made by the time compact is called, so a failure to compact is not
+// returned: a failure that leaves the log unusable stops further operations,
+// and any other failure is retried after another Options.CompactAfter
+// records.
+func (q *Queue[T]) compact() {
+	if q.opts.CompactAfter < 0 || q.records < q.compactAt || q.records < q.d.Len() {
+		return
+	}
+	if err := q.writeSnapshot(); err != nil {
+		q.compactAt = q.records + q.opts.CompactAfter
+	}
+}
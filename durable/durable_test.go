@@ -0,0 +1,350 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package durable
+
+import (
+	"errors"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"testing"
+
+	"github.com/aalpar/deheap"
+)
+
+func intCmp(a, b int) int {
+	return a - b
+}
+
+// drain pops all the elements of q in ascending order
+func drain(t *testing.T, q *Queue[int]) []int {
+	t.Helper()
+	r := []int{}
+	for q.Len() > 0 {
+		x, err := q.PopMin()
+		if err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		r = append(r, x)
+	}
+	return r
+}
+
+func open(t *testing.T, dir string, opts Options) *Queue[int] {
+	t.Helper()
+	q, err := Open(dir, intCmp, opts)
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	return q
+}
+
+// randomOps makes n random operations on q and on the sorted slice ref
+func randomOps(t *testing.T, s *rand.Rand, q *Queue[int], ref []int, n int) []int {
+	t.Helper()
+	for i := 0; i < n; i++ {
+		switch op := s.Intn(6); {
+		case op < 3 || len(ref) == 0:
+			x := s.Intn(1000)
+			if err := q.Push(x); err != nil {
+				t.Fatalf("unexpected value: %v", err)
+			}
+			ref = append(ref, x)
+			sort.Ints(ref)
+		case op == 3:
+			if x, err := q.PopMin(); err != nil || x != ref[0] {
+				t.Fatalf("unexpected value: %d %v %d", x, err, ref[0])
+			}
+			ref = ref[1:]
+		case op == 4:
+			if x, err := q.PopMax(); err != nil || x != ref[len(ref)-1] {
+				t.Fatalf("unexpected value: %d %v %d", x, err, ref[len(ref)-1])
+			}
+			ref = ref[:len(ref)-1]
+		default:
+			x, err := q.Remove(s.Intn(q.Len()))
+			if err != nil {
+				t.Fatalf("unexpected value: %v", err)
+			}
+			j := sort.SearchInts(ref, x)
+			ref = append(ref[:j], ref[j+1:]...)
+		}
+	}
+	return ref
+}
+
+func TestReopen(t *testing.T) {
+
+	s := rand.New(rand.NewSource(0))
+
+	for _, opts := range []Options{
+		{},
+		{Sync: SyncBatched, BatchSize: 7},
+		{Sync: SyncNone, CompactAfter: -1},
+		{Sync: SyncNone, CompactAfter: 16},
+	} {
+		dir := t.TempDir()
+		ref := []int{}
+		for k := 0; k < 5; k++ {
+			q := open(t, dir, opts)
+			if q.Len() != len(ref) {
+				t.Fatalf("unexpected value: %d %d", q.Len(), len(ref))
+			}
+			ref = randomOps(t, s, q, ref, 200)
+			if err := q.Close(); err != nil {
+				t.Fatalf("unexpected value: %v", err)
+			}
+		}
+		q := open(t, dir, opts)
+		if r := drain(t, q); !slices.Equal(r, ref) {
+			t.Fatalf("unexpected value: %v %v", r, ref)
+		}
+		q.Close()
+	}
+
+}
+
+func TestTornTail(t *testing.T) {
+
+	dir := t.TempDir()
+	q := open(t, dir, Options{CompactAfter: -1})
+	for _, x := range []int{5, 3, 8} {
+		if err := q.Push(x); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+	q.Close()
+
+	name := filepath.Join(dir, logName)
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	full := len(b)
+	// a last record torn anywhere or with a bad checksum, or zeros after the
+	// last record, are dropped
+	for _, c := range [][]byte{
+		b[:full-1],
+		b[:full-10],
+		append(append([]byte{}, b...), encodeRecord(opPopMin, nil)[:5]...),
+		append(b[:full-1:full-1], b[full-1]^1),
+		append(append([]byte{}, b...), make([]byte, 20)...),
+	} {
+		if err := os.WriteFile(name, c, 0o644); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		q := open(t, dir, Options{CompactAfter: -1})
+		want := 2
+		if len(c) > full {
+			want = 3
+		}
+		if q.Len() != want || q.size > int64(len(c)) {
+			t.Fatalf("unexpected value: %d %d", q.Len(), q.size)
+		}
+		if fi, err := os.Stat(name); err != nil || fi.Size() != q.size {
+			t.Fatalf("unexpected value: %v %v", fi, err)
+		}
+		// records appended after the truncation are replayed
+		if err := q.Push(1); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		q.Close()
+		q = open(t, dir, Options{CompactAfter: -1})
+		if x, err := q.PeekMin(); err != nil || x != 1 || q.Len() != want+1 {
+			t.Fatalf("unexpected value: %d %v %d", x, err, q.Len())
+		}
+		q.Close()
+		if err := os.WriteFile(name, b, 0o644); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+
+}
+
+func TestCorruptRecord(t *testing.T) {
+
+	dir := t.TempDir()
+	q := open(t, dir, Options{CompactAfter: -1})
+	for i := 0; i < 10; i++ {
+		if err := q.Push(i); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+	q.Close()
+
+	name := filepath.Join(dir, logName)
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	_, _, l, ok := decodeRecord(b[logHeader:])
+	if !ok {
+		t.Fatalf("unexpected value: %d", l)
+	}
+	// damage to any record but the last one, in its checksum, payload or
+	// length, is not a torn write
+	for _, i := range []int{logHeader + l + 4, logHeader + l + 10, logHeader + l + 3, logHeader + 5} {
+		c := append([]byte{}, b...)
+		c[i] ^= 1
+		if err := os.WriteFile(name, c, 0o644); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		if _, err := Open(dir, intCmp, Options{}); !errors.Is(err, deheap.ErrCorrupt) {
+			t.Fatalf("unexpected value: %d %v", i, err)
+		}
+		if fi, err := os.Stat(name); err != nil || fi.Size() != int64(len(b)) {
+			t.Fatalf("unexpected value: %v %v", fi, err)
+		}
+	}
+
+}
+
+func TestCompact(t *testing.T) {
+
+	s := rand.New(rand.NewSource(0))
+
+	dir := t.TempDir()
+	q := open(t, dir, Options{Sync: SyncNone, CompactAfter: -1})
+	ref := randomOps(t, s, q, nil, 500)
+	old, err := os.ReadFile(filepath.Join(dir, logName))
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if err := q.Compact(); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if q.gen != 1 || q.records != 0 || q.size != int64(logHeader) {
+		t.Fatalf("unexpected value: %d %d %d", q.gen, q.records, q.size)
+	}
+	q.Close()
+
+	// a crash after the snapshot was replaced but before the log was
+	// leaves a log of the previous generation, which is ignored
+	if err := os.WriteFile(filepath.Join(dir, logName), old, 0o644); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, logName+tmpSuffix), nil, 0o644); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	q = open(t, dir, Options{})
+	if q.Len() != len(ref) || q.records != 0 {
+		t.Fatalf("unexpected value: %d %d", q.Len(), q.records)
+	}
+	if _, err := os.Stat(filepath.Join(dir, logName+tmpSuffix)); !errors.Is(err, os.ErrNotExist) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	ref = randomOps(t, s, q, ref, 100)
+	q.Close()
+	q = open(t, dir, Options{})
+	if r := drain(t, q); !slices.Equal(r, ref) {
+		t.Fatalf("unexpected value: %v %v", r, ref)
+	}
+	q.Close()
+
+	// automatic compaction keeps the log short
+	dir = t.TempDir()
+	q = open(t, dir, Options{Sync: SyncNone, CompactAfter: 50})
+	ref = randomOps(t, s, q, nil, 1000)
+	if q.gen == 0 || q.records > 50+len(ref) {
+		t.Fatalf("unexpected value: %d %d", q.gen, q.records)
+	}
+	q.Close()
+	q = open(t, dir, Options{})
+	if r := drain(t, q); !slices.Equal(r, ref) {
+		t.Fatalf("unexpected value: %v %v", r, ref)
+	}
+	q.Close()
+
+}
+
+func TestCorrupt(t *testing.T) {
+
+	dir := t.TempDir()
+	q := open(t, dir, Options{})
+	q.Push(1)
+	q.Compact()
+	q.Push(2)
+	q.Close()
+
+	name := filepath.Join(dir, snapshotName)
+	b, err := os.ReadFile(name)
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	b[len(b)-1] ^= 1
+	if err := os.WriteFile(name, b, 0o644); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if _, err := Open(dir, intCmp, Options{}); !errors.Is(err, deheap.ErrCorrupt) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	// a checksummed record that cannot be applied is not a torn write
+	dir = t.TempDir()
+	q = open(t, dir, Options{})
+	q.Close()
+	f, err := os.OpenFile(filepath.Join(dir, logName), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	f.Write(encodeRecord(opPopMax, nil))
+	f.Close()
+	if _, err := Open(dir, intCmp, Options{}); !errors.Is(err, deheap.ErrCorrupt) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+}
+
+func TestErrors(t *testing.T) {
+
+	q := open(t, t.TempDir(), Options{})
+	if _, err := q.PopMin(); !errors.Is(err, deheap.ErrEmpty) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if _, err := q.PeekMax(); !errors.Is(err, deheap.ErrEmpty) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if _, err := q.Remove(0); !errors.Is(err, deheap.ErrIndexOutOfRange) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if q.records != 0 {
+		t.Fatalf("unexpected value: %d", q.records)
+	}
+	q.Push(1)
+	if x, err := q.At(0); err != nil || x != 1 {
+		t.Fatalf("unexpected value: %d %v", x, err)
+	}
+	if err := q.Close(); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if err := q.Push(2); !errors.Is(err, deheap.ErrClosed) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if err := q.Close(); !errors.Is(err, deheap.ErrClosed) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+}
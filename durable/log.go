@@ -0,0 +1,342 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package durable
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"os"
+	"path/filepath"
+
+	"github.com/aalpar/deheap"
+)
+
+// A directory holds a snapshot and a log, each with a generation number.
+// Compaction writes a snapshot and an empty log of the next generation to
+// temporary files, and renames them over the old ones, snapshot first.  A
+// log older than the snapshot was compacted into it before a crash, and is
+// replaced by an empty log when the queue is opened.
+//
+// The snapshot is the magic bytes, the big-endian generation, the IEEE
+// CRC-32 of the generation and the body, and the body, which is the binary
+// encoding of the deheap.
+//
+// The log is the magic bytes, a version byte and the big-endian generation,
+// followed by records.  A record is the big-endian length of its operation
+// and payload, their IEEE CRC-32, the operation byte and the payload.
+const (
+	snapshotName  = "snapshot"
+	snapshotMagic = "DHSN"
+	logName       = "log"
+	logMagic      = "DHWL"
+	logVersion    = 1
+	logHeader     = len(logMagic) + 1 + 8
+	tmpSuffix     = ".tmp"
+)
+
+// operations of the log records
+const (
+	// opPush has the gob encoding of the pushed element as its payload
+	opPush byte = iota + 1
+	opPopMin
+	opPopMax
+	// opRemove has the uvarint index of the removed element as its payload
+	opRemove
+)
+
+func encodeRecord(op byte, payload []byte) []byte {
+	r := make([]byte, 9, 9+len(payload))
+	r[8] = op
+	r = append(r, payload...)
+	binary.BigEndian.PutUint32(r, uint32(len(r)-8))
+	binary.BigEndian.PutUint32(r[4:], crc32.ChecksumIEEE(r[8:]))
+	return r
+}
+
+// decodeRecord returns the operation and payload of the record at the start
+// of b and the length of the record, or ok false if b does not start with a
+// whole record with a valid checksum
+func decodeRecord(b []byte) (op byte, payload []byte, n int, ok bool) {
+	if len(b) < 9 {
+		return 0, nil, 0, false
+	}
+	l := binary.BigEndian.Uint32(b)
+	if l == 0 || uint64(l) > uint64(len(b)-8) {
+		return 0, nil, 0, false
+	}
+	r := b[8 : 8+l]
+	if binary.BigEndian.Uint32(b[4:]) != crc32.ChecksumIEEE(r) {
+		return 0, nil, 0, false
+	}
+	return r[0], r[1:], 8 + int(l), true
+}
+
+// torn reports whether b, which does not start with a valid record, is what
+// an interrupted write leaves at the end of the log: a record whose length
+// reaches the end of the log, or only zeros.  Anything else is corruption
+// of records that were written in full, and must not be truncated.
+func torn(b []byte) bool {
+	if len(b) < 8 || uint64(binary.BigEndian.Uint32(b))+8 >= uint64(len(b)) {
+		return true
+	}
+	for _, c := range b {
+		if c != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func encodeElement[T any](x T) ([]byte, error) {
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(&x); err != nil {
+		return nil, err
+	}
+	return b.Bytes(), nil
+}
+
+func encodeIndex(i int) []byte {
+	return binary.AppendUvarint(nil, uint64(i))
+}
+
+// apply makes the change of a replayed record
+func (q *Queue[T]) apply(op byte, payload []byte) error {
+	switch op {
+	case opPush:
+		var x T
+		if err := gob.NewDecoder(bytes.NewReader(payload)).Decode(&x); err != nil {
+			return err
+		}
+		q.d.Push(x)
+	case opPopMin, opPopMax:
+		if q.d.Len() == 0 {
+			return deheap.ErrEmpty
+		}
+		if op == opPopMin {
+			q.d.PopMin()
+		} else {
+			q.d.PopMax()
+		}
+	case opRemove:
+		i, n := binary.Uvarint(payload)
+		if n <= 0 || i >= uint64(q.d.Len()) {
+			return deheap.ErrIndexOutOfRange
+		}
+		q.d.Remove(int(i))
+	default:
+		return fmt.Errorf("unknown operation %d", op)
+	}
+	return nil
+}
+
+// readSnapshot loads the snapshot of the directory, if there is one
+func (q *Queue[T]) readSnapshot() error {
+	b, err := os.ReadFile(filepath.Join(q.dir, snapshotName))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if len(b) < len(snapshotMagic)+12 || string(b[:len(snapshotMagic)]) != snapshotMagic {
+		return fmt.Errorf("%w: bad snapshot header", deheap.ErrCorrupt)
+	}
+	b = b[len(snapshotMagic):]
+	if binary.BigEndian.Uint32(b[8:]) != crc32.Update(crc32.ChecksumIEEE(b[:8]), crc32.IEEETable, b[12:]) {
+		return fmt.Errorf("%w: snapshot checksum mismatch", deheap.ErrCorrupt)
+	}
+	if err := q.d.UnmarshalBinary(b[12:]); err != nil {
+		return fmt.Errorf("snapshot: %w", err)
+	}
+	q.gen = binary.BigEndian.Uint64(b)
+	return nil
+}
+
+// openLog replays the log of the directory, truncating a torn record at its
+// end, and opens it for appending.  A bad record anywhere else returns an
+// error wrapping deheap.ErrCorrupt and leaves the log as it is.  A missing log, or one older than the
+// snapshot, is replaced by an empty one.
+func (q *Queue[T]) openLog() error {
+	name := filepath.Join(q.dir, logName)
+	b, err := os.ReadFile(name)
+	if errors.Is(err, os.ErrNotExist) {
+		return q.createLog(q.gen)
+	}
+	if err != nil {
+		return err
+	}
+	if len(b) < logHeader || string(b[:len(logMagic)]) != logMagic {
+		return fmt.Errorf("%w: bad log header", deheap.ErrCorrupt)
+	}
+	if v := b[len(logMagic)]; v != logVersion {
+		return fmt.Errorf("%w: unknown log version %d", deheap.ErrCorrupt, v)
+	}
+	switch gen := binary.BigEndian.Uint64(b[len(logMagic)+1:]); {
+	case gen < q.gen:
+		return q.createLog(q.gen)
+	case gen > q.gen:
+		return fmt.Errorf("%w: log generation %d is newer than snapshot generation %d", deheap.ErrCorrupt, gen, q.gen)
+	}
+	n := logHeader
+	for {
+		op, payload, l, ok := decodeRecord(b[n:])
+		if !ok {
+			if !torn(b[n:]) {
+				return fmt.Errorf("%w: bad log record at offset %d", deheap.ErrCorrupt, n)
+			}
+			break
+		}
+		if err := q.apply(op, payload); err != nil {
+			return fmt.Errorf("%w: log record at offset %d: %w", deheap.ErrCorrupt, n, err)
+		}
+		n += l
+		q.records++
+	}
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	if n < len(b) {
+		if err := truncate(f, int64(n)); err != nil {
+			f.Close()
+			return err
+		}
+	}
+	q.log = f
+	q.size = int64(n)
+	return nil
+}
+
+func truncate(f *os.File, n int64) error {
+	if err := f.Truncate(n); err != nil {
+		return err
+	}
+	return f.Sync()
+}
+
+// createLog replaces the log of the directory with an empty log of
+// generation gen, and opens it for appending
+func (q *Queue[T]) createLog(gen uint64) error {
+	h := make([]byte, 0, logHeader)
+	h = append(h, logMagic...)
+	h = append(h, logVersion)
+	h = binary.BigEndian.AppendUint64(h, gen)
+	tmp := filepath.Join(q.dir, logName+tmpSuffix)
+	if err := writeFile(tmp, h); err != nil {
+		return err
+	}
+	if err := q.rename(tmp, logName); err != nil {
+		return err
+	}
+	f, err := os.OpenFile(filepath.Join(q.dir, logName), os.O_WRONLY|os.O_APPEND, 0)
+	if err != nil {
+		return err
+	}
+	q.log = f
+	q.gen = gen
+	q.size = int64(len(h))
+	q.records = 0
+	q.unsynced = 0
+	return nil
+}
+
+// writeSnapshot writes a snapshot of the queue of the next generation and
+// starts an empty log.  A failure before the snapshot replaces the old one
+// leaves the old log in use; a failure after it stops all further
+// operations, as the old log no longer matches the snapshot.
+func (q *Queue[T]) writeSnapshot() error {
+	body, err := q.d.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	gen := q.gen + 1
+	b := make([]byte, 0, len(snapshotMagic)+12+len(body))
+	b = append(b, snapshotMagic...)
+	b = binary.BigEndian.AppendUint64(b, gen)
+	b = binary.BigEndian.AppendUint32(b, crc32.Update(crc32.ChecksumIEEE(b[len(snapshotMagic):]), crc32.IEEETable, body))
+	b = append(b, body...)
+	tmp := filepath.Join(q.dir, snapshotName+tmpSuffix)
+	if err := writeFile(tmp, b); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := os.Rename(tmp, filepath.Join(q.dir, snapshotName)); err != nil {
+		os.Remove(tmp)
+		return err
+	}
+	if err := syncDir(q.dir); err != nil {
+		q.err = err
+		return err
+	}
+	old := q.log
+	if err := q.createLog(gen); err != nil {
+		q.err = err
+		return err
+	}
+	q.compactAt = q.opts.CompactAfter
+	return old.Close()
+}
+
+// writeFile writes b to a new file and syncs it
+func writeFile(name string, b []byte) error {
+	f, err := os.OpenFile(name, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o644)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// rename renames the file tmp to name in the directory, and syncs the
+// directory to make the rename durable
+func (q *Queue[T]) rename(tmp, name string) error {
+	if err := os.Rename(tmp, filepath.Join(q.dir, name)); err != nil {
+		return err
+	}
+	return syncDir(q.dir)
+}
+
+// syncDir syncs the directory dir
+func syncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	if err := d.Sync(); err != nil {
+		d.Close()
+		return err
+	}
+	return d.Close()
+}
@@ -0,0 +1,154 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/crc32"
+)
+
+// ErrCorrupt is returned when decoding data that is not a valid encoding of
+// a deheap.
+var ErrCorrupt = errors.New("deheap: corrupt data")
+
+// ErrNoCmp is returned when decoding into a Deheap that has no comparison
+// function to check the order of the decoded elements with, such as the zero
+// Deheap that encoding/gob and encoding/json allocate for a nil *Deheap field.
+var ErrNoCmp = errors.New("deheap: decoding into a Deheap without a comparison function, create it with New")
+
+// The binary encoding of a Deheap is a header of the magic bytes, a version
+// byte and the big-endian IEEE CRC-32 of the payload, followed by the payload,
+// the gob encoding of the elements in heap order.  The JSON encoding is an
+// object with the version and the elements in heap order:
+//
+//	{"version":1,"items":[...]}
+const (
+	encodingMagic   = "DEHP"
+	encodingVersion = 1
+	encodingHeader  = len(encodingMagic) + 1 + 4
+)
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The elements are
+// stored in heap order and are encoded with encoding/gob.
+func (d *Deheap[T]) MarshalBinary() ([]byte, error) {
+	var b bytes.Buffer
+	b.WriteString(encodingMagic)
+	b.WriteByte(encodingVersion)
+	b.Write(make([]byte, 4))
+	if err := gob.NewEncoder(&b).Encode(d.data); err != nil {
+		return nil, err
+	}
+	p := b.Bytes()
+	binary.BigEndian.PutUint32(p[encodingHeader-4:], crc32.ChecksumIEEE(p[encodingHeader:]))
+	return p, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler.  It replaces the
+// elements of d with those of data, which must have been written by
+// MarshalBinary for a deheap with the same ordering.  The elements are
+// checked with Verify instead of being reordered, and an error wrapping
+// ErrCorrupt is returned if data is damaged or out of order.  d must have
+// been created with New or NewLess, and ErrNoCmp is returned otherwise.
+// When d is a field of a struct being decoded with encoding/gob or
+// encoding/json, set the field to a new Deheap before decoding, because a nil
+// field is decoded into a zero Deheap.  d is left unchanged on error.
+// Time complexity is O(n), where n is the number of elements in data
+func (d *Deheap[T]) UnmarshalBinary(data []byte) error {
+	if d.cmp == nil {
+		return ErrNoCmp
+	}
+	if len(data) < encodingHeader || string(data[:len(encodingMagic)]) != encodingMagic {
+		return fmt.Errorf("%w: bad header", ErrCorrupt)
+	}
+	if v := data[len(encodingMagic)]; v != encodingVersion {
+		return fmt.Errorf("%w: unknown version %d", ErrCorrupt, v)
+	}
+	p := data[encodingHeader:]
+	if binary.BigEndian.Uint32(data[encodingHeader-4:]) != crc32.ChecksumIEEE(p) {
+		return fmt.Errorf("%w: checksum mismatch", ErrCorrupt)
+	}
+	var items []T
+	if err := gob.NewDecoder(bytes.NewReader(p)).Decode(&items); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	return d.load(items)
+}
+
+// GobEncode implements gob.GobEncoder.  See MarshalBinary().
+func (d *Deheap[T]) GobEncode() ([]byte, error) {
+	return d.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.  See UnmarshalBinary().
+func (d *Deheap[T]) GobDecode(data []byte) error {
+	return d.UnmarshalBinary(data)
+}
+
+// jsonDeheap is the JSON encoding of a Deheap
+type jsonDeheap[T any] struct {
+	Version int `json:"version"`
+	Items   []T `json:"items"`
+}
+
+// MarshalJSON implements json.Marshaler.  The elements are stored in heap
+// order.
+func (d *Deheap[T]) MarshalJSON() ([]byte, error) {
+	items := d.data
+	if items == nil {
+		items = []T{}
+	}
+	return json.Marshal(jsonDeheap[T]{Version: encodingVersion, Items: items})
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  It returns ErrNoCmp when d
+// was not created with New or NewLess, as for a nil *Deheap field that
+// encoding/json allocates.  See UnmarshalBinary().
+// Time complexity is O(n), where n is the number of elements in data
+func (d *Deheap[T]) UnmarshalJSON(data []byte) error {
+	if d.cmp == nil {
+		return ErrNoCmp
+	}
+	var j jsonDeheap[T]
+	if err := json.Unmarshal(data, &j); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	if j.Version != encodingVersion {
+		return fmt.Errorf("%w: unknown version %d", ErrCorrupt, j.Version)
+	}
+	return d.load(j.Items)
+}
+
+// load replaces the elements of d with items if they are in heap order
+func (d *Deheap[T]) load(items []T) error {
+	if err := (&Deheap[T]{data: items, cmp: d.cmp}).Verify(); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorrupt, err)
+	}
+	d.data = items
+	return nil
+}
@@ -0,0 +1,182 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestDeheapMarshalBinary(t *testing.T) {
+
+	s := _newRand()
+
+	for _, n := range []int{0, 1, 2, 10, 1000} {
+		d := New(intCmp)
+		for i := 0; i < n; i++ {
+			d.Push(s.Intn(100))
+		}
+		b, err := d.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		e := New(intCmp)
+		e.Push(1)
+		if err := e.UnmarshalBinary(b); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		if e.Len() != n || (n > 0 && !reflect.DeepEqual(e.data, d.data)) {
+			t.Fatalf("unexpected value: %v %v", e.data, d.data)
+		}
+	}
+
+}
+
+func TestDeheapUnmarshalBinaryCorrupt(t *testing.T) {
+
+	d := New(intCmp)
+	for _, x := range []int{5, 3, 8, 1, 9, 2, 7} {
+		d.Push(x)
+	}
+	b, err := d.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	flip := func(i int) []byte {
+		c := append([]byte{}, b...)
+		c[i] ^= 0x10
+		return c
+	}
+	for _, c := range [][]byte{nil, b[:5], b[:len(b)-1], flip(0), flip(4), flip(6), flip(len(b) - 1)} {
+		e := New(intCmp)
+		e.Push(42)
+		if err := e.UnmarshalBinary(c); !errors.Is(err, ErrCorrupt) {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		if !reflect.DeepEqual(e.data, []int{42}) {
+			t.Fatalf("unexpected value: %v", e.data)
+		}
+	}
+
+	// a heap ordered the other way has a valid checksum but is out of order
+	r := New(func(a, b int) int { return intCmp(b, a) })
+	r.Push(1)
+	r.Push(2)
+	b, err = r.MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	var ie *InvariantError
+	if err := New(intCmp).UnmarshalBinary(b); !errors.Is(err, ErrCorrupt) || !errors.As(err, &ie) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	if err := new(Deheap[int]).UnmarshalBinary(b); !errors.Is(err, ErrNoCmp) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+}
+
+func TestDeheapGob(t *testing.T) {
+
+	type state struct {
+		Name  string
+		Queue *Deheap[string]
+	}
+
+	d := New(func(a, b string) int { return len(a) - len(b) })
+	for _, x := range []string{"ccc", "a", "dddd", "bb"} {
+		d.Push(x)
+	}
+	var b bytes.Buffer
+	if err := gob.NewEncoder(&b).Encode(state{Name: "x", Queue: d}); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	st := state{Queue: New(func(a, b string) int { return len(a) - len(b) })}
+	if err := gob.NewDecoder(&b).Decode(&st); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if st.Name != "x" || st.Queue.PopMin() != "a" || st.Queue.PopMax() != "dddd" {
+		t.Fatalf("unexpected value: %v", st)
+	}
+
+	b.Reset()
+	if err := gob.NewEncoder(&b).Encode(state{Name: "x", Queue: d}); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if err := gob.NewDecoder(&b).Decode(&state{}); !errors.Is(err, ErrNoCmp) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+}
+
+func TestDeheapJSON(t *testing.T) {
+
+	d := New(intCmp)
+	b, err := json.Marshal(d)
+	if err != nil || string(b) != `{"version":1,"items":[]}` {
+		t.Fatalf("unexpected value: %s %v", b, err)
+	}
+	for _, x := range []int{5, 3, 8, 1} {
+		d.Push(x)
+	}
+	b, err = json.Marshal(d)
+	if err != nil || string(b) != `{"version":1,"items":[1,5,8,3]}` {
+		t.Fatalf("unexpected value: %s %v", b, err)
+	}
+
+	var st struct{ Queue *Deheap[int] }
+	if err := json.Unmarshal([]byte(`{"Queue":`+string(b)+`}`), &st); !errors.Is(err, ErrNoCmp) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	e := New(intCmp)
+	if err := json.Unmarshal(b, e); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if !reflect.DeepEqual(e.data, d.data) {
+		t.Fatalf("unexpected value: %v", e.data)
+	}
+
+	for _, c := range []string{
+		`{"version":1,"items":[3,1,2]}`,
+		`{"version":2,"items":[1,3,2]}`,
+		`{"items":[1,3,2]}`,
+		`{"version":1,"items":["a"]}`,
+		`[1,3,2]`,
+	} {
+		if err := json.Unmarshal([]byte(c), e); !errors.Is(err, ErrCorrupt) {
+			t.Fatalf("unexpected value: %s %v", c, err)
+		}
+		if !reflect.DeepEqual(e.data, d.data) {
+			t.Fatalf("unexpected value: %v", e.data)
+		}
+	}
+
+}
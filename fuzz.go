@@ -1,7 +1,7 @@
 // Run fuzz tests on the package
 //
-// This is done with a byte heap to test and a simple reimplementation
-// to check correctness against.
+// This is done with a byte heap and a SymmetricHeap of bytes to test and a
+// simple reimplementation to check correctness against.
 //
 // First install go-fuzz
 //
@@ -17,7 +17,7 @@
 //
 // See https://github.com/dvyukov/go-fuzz for more instructions
 
-//+build gofuzz
+//go:build gofuzz
 
 package deheap
 
@@ -81,6 +81,7 @@ func (h *sortedHeap) PopMax() (x byte) {
 func Fuzz(data []byte) int {
 	h := &byteDeheap{}
 	Init(h)
+	sh := NewSymmetric(func(a, b byte) int { return int(a) - int(b) })
 	s := sortedHeap{}
 
 	for _, c := range data {
@@ -92,6 +93,9 @@ func Fuzz(data []byte) int {
 				if got != want {
 					panic(fmt.Sprintf("Pop: want = %d, got = %d", want, got))
 				}
+				if got := sh.PopMin(); got != want {
+					panic(fmt.Sprintf("SymmetricHeap PopMin: want = %d, got = %d", want, got))
+				}
 			}
 		case '>':
 			if h.Len() > 0 {
@@ -100,14 +104,21 @@ func Fuzz(data []byte) int {
 				if got != want {
 					panic(fmt.Sprintf("PopMax: want = %d, got = %d", want, got))
 				}
+				if got := sh.PopMax(); got != want {
+					panic(fmt.Sprintf("SymmetricHeap PopMax: want = %d, got = %d", want, got))
+				}
 			}
 		default:
 			Push(h, c)
+			sh.Push(c)
 			s.Push(c)
 		}
-		if len(s) != h.Len() {
+		if len(s) != h.Len() || len(s) != sh.Len() {
 			panic("wrong length")
 		}
+		if err := sh.Verify(); err != nil {
+			panic(err)
+		}
 	}
 	return 1
 }
@@ -1,3 +1,3 @@
 module github.com/aalpar/deheap
 
-go 1.13
+go 1.23
@@ -0,0 +1,177 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+// Handle refers to an element of an IndexedDeheap.  A handle keeps referring
+// to its element while the element moves within the deheap, so the element
+// can be found, changed or removed in O(1) plus the cost of restoring the
+// ordering.
+type Handle[T any] struct {
+	value T
+	index int
+	owner *IndexedDeheap[T]
+}
+
+// Value returns the element the handle refers to.
+func (h *Handle[T]) Value() T {
+	return h.value
+}
+
+// IndexedDeheap is a type-safe doubly ended heap that returns a Handle for
+// every element pushed onto it.
+type IndexedDeheap[T any] struct {
+	data []*Handle[T]
+	cmp  func(a, b T) int
+}
+
+// NewIndexed returns an empty IndexedDeheap ordered by cmp.  See New().
+func NewIndexed[T any](cmp func(a, b T) int) *IndexedDeheap[T] {
+	return &IndexedDeheap[T]{cmp: cmp}
+}
+
+// indexedSlice adapts an IndexedDeheap to the sort.Interface used by the
+// deheap algorithms, keeping the handle indexes up to date
+type indexedSlice[T any] IndexedDeheap[T]
+
+func (d *indexedSlice[T]) Len() int           { return len(d.data) }
+func (d *indexedSlice[T]) Less(i, j int) bool { return d.cmp(d.data[i].value, d.data[j].value) < 0 }
+func (d *indexedSlice[T]) Swap(i, j int) {
+	d.data[i], d.data[j] = d.data[j], d.data[i]
+	d.data[i].index = i
+	d.data[j].index = j
+}
+
+func (d *IndexedDeheap[T]) slice() *indexedSlice[T] {
+	return (*indexedSlice[T])(d)
+}
+
+// Len returns the number of elements in the deheap.
+func (d *IndexedDeheap[T]) Len() int {
+	return len(d.data)
+}
+
+// Contains reports whether h refers to an element of the deheap.  Handles
+// of elements that have been popped or removed are no longer contained.
+func (d *IndexedDeheap[T]) Contains(h *Handle[T]) bool {
+	return h.owner == d
+}
+
+// Push an element onto the deheap and return its handle.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IndexedDeheap[T]) Push(x T) *Handle[T] {
+	h := &Handle[T]{value: x}
+	d.push(h)
+	return h
+}
+
+func (d *IndexedDeheap[T]) push(h *Handle[T]) {
+	h.owner = d
+	h.index = len(d.data)
+	d.data = append(d.data, h)
+	bubbleup(d.slice(), isMinHeap(h.index), h.index)
+	if debug {
+		debugf(d.slice(), "Push(%v)", h.value)
+	}
+}
+
+// PeekMin returns the smallest element without removing it.
+func (d *IndexedDeheap[T]) PeekMin() T {
+	checkEmpty(len(d.data), "PeekMin")
+	return d.data[0].value
+}
+
+// PeekMax returns the largest element without removing it.
+func (d *IndexedDeheap[T]) PeekMax() T {
+	checkEmpty(len(d.data), "PeekMax")
+	return d.data[maxIndex(d.slice(), len(d.data))].value
+}
+
+// PopMin removes and returns the smallest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IndexedDeheap[T]) PopMin() T {
+	checkEmpty(len(d.data), "PopMin")
+	return d.remove(0).value
+}
+
+// PopMax removes and returns the largest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IndexedDeheap[T]) PopMax() T {
+	checkEmpty(len(d.data), "PopMax")
+	return d.remove(maxIndex(d.slice(), len(d.data))).value
+}
+
+// Delete removes the element h refers to and returns it.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IndexedDeheap[T]) Delete(h *Handle[T]) T {
+	d.check(h)
+	return d.remove(h.index).value
+}
+
+// Update changes the element h refers to to x and re-establishes the
+// ordering.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IndexedDeheap[T]) Update(h *Handle[T], x T) {
+	d.check(h)
+	h.value = x
+	fix(d.slice(), len(d.data), h.index)
+	if debug {
+		debugf(d.slice(), "Update(%d, %v)", h.index, x)
+	}
+}
+
+// Fix re-establishes the ordering after the element h refers to has changed
+// its value, for instance through a pointer held by the caller.  See Fix().
+// Time complexity is O(log n), where n = d.Len()
+func (d *IndexedDeheap[T]) Fix(h *Handle[T]) {
+	d.check(h)
+	fix(d.slice(), len(d.data), h.index)
+	if debug {
+		debugf(d.slice(), "Fix(%d)", h.index)
+	}
+}
+
+func (d *IndexedDeheap[T]) check(h *Handle[T]) {
+	if h.owner != d {
+		panic("deheap: handle does not refer to an element of this deheap")
+	}
+}
+
+func (d *IndexedDeheap[T]) remove(i int) *Handle[T] {
+	l := len(d.data) - 1
+	h := d.data[i]
+	if i != l {
+		d.slice().Swap(i, l)
+	}
+	d.data[l] = nil
+	d.data = d.data[:l]
+	if i != l {
+		fix(d.slice(), l, i)
+	}
+	if debug {
+		debugf(d.slice(), "Remove(%d) = %v", i, h.value)
+	}
+	h.owner = nil
+	h.index = -1
+	return h
+}
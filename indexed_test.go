@@ -0,0 +1,120 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"testing"
+)
+
+func checkIndexed(t *testing.T, d *IndexedDeheap[int]) {
+	t.Helper()
+	for i, h := range d.data {
+		if h.index != i || h.owner != d {
+			t.Fatalf("unexpected value: %d %d", i, h.index)
+		}
+	}
+	if x, y, ok := isDeheap(t, d.slice()); !ok {
+		t.Fatalf("unexpected value: %d %d", x, y)
+	}
+}
+
+func TestIndexedDeheap(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		d := NewIndexed(intCmp)
+		N := s.Intn(64) + 1
+		hs := []*Handle[int]{}
+		for i := 0; i < N; i++ {
+			hs = append(hs, d.Push(s.Intn(N)))
+			checkIndexed(t, d)
+		}
+		for d.Len() > 0 {
+			j := s.Intn(len(hs))
+			h := hs[j]
+			switch s.Intn(5) {
+			case 0:
+				x := d.PeekMin()
+				if y := d.PopMin(); y != x {
+					t.Fatalf("unexpected value: %d %d", x, y)
+				}
+			case 1:
+				x := d.PeekMax()
+				if y := d.PopMax(); y != x {
+					t.Fatalf("unexpected value: %d %d", x, y)
+				}
+			case 2:
+				if x := d.Delete(h); x != h.Value() {
+					t.Fatalf("unexpected value: %d %d", x, h.Value())
+				}
+			default:
+				d.Update(h, s.Intn(N))
+			}
+			checkIndexed(t, d)
+			hs = hs[:0]
+			for _, h := range d.data {
+				hs = append(hs, h)
+			}
+			if !d.Contains(h) && h.index != -1 {
+				t.Fatalf("unexpected value: %d", h.index)
+			}
+		}
+	}
+
+}
+
+func TestIndexedDeheapHandles(t *testing.T) {
+
+	d := NewIndexed(intCmp)
+	h5 := d.Push(5)
+	h1 := d.Push(1)
+	h9 := d.Push(9)
+	h3 := d.Push(3)
+
+	d.Update(h9, 0)
+	if d.PeekMin() != 0 || d.PeekMax() != 5 {
+		t.Fatalf("unexpected value: %d %d", d.PeekMin(), d.PeekMax())
+	}
+	if x := d.Delete(h5); x != 5 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if d.Contains(h5) {
+		t.Fatalf("unexpected value")
+	}
+	if x := d.PopMax(); x != 3 || d.Contains(h3) {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if !d.Contains(h1) || h1.Value() != 1 {
+		t.Fatalf("unexpected value")
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("expected panic")
+		}
+	}()
+	d.Delete(h5)
+
+}
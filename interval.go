@@ -0,0 +1,268 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"math/bits"
+)
+
+// IntervalHeap is a type-safe doubly ended heap laid out as an interval
+// heap.  It offers the same operations as Deheap's PriorityQueue methods and
+// can be used in its place.
+//
+// Each node of an interval heap holds a pair of elements lo <= hi, and the
+// interval [lo, hi] of every node contains the intervals of its children.
+// Both the smallest and the largest element are in the root, so PopMin and
+// PopMax do the same amount of work and each moves an element down a tree
+// of half the height of a binary heap.  Node k holds the elements at
+// indexes 2k and 2k+1; the last node holds a single element when the
+// number of elements is odd.
+type IntervalHeap[T any] struct {
+	data []T
+	cmp  func(a, b T) int
+}
+
+// NewInterval returns an empty IntervalHeap ordered by cmp.  See New().
+func NewInterval[T any](cmp func(a, b T) int) *IntervalHeap[T] {
+	return &IntervalHeap[T]{cmp: cmp}
+}
+
+// Len returns the number of elements in the heap.
+func (d *IntervalHeap[T]) Len() int {
+	return len(d.data)
+}
+
+// Push an element onto the heap.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IntervalHeap[T]) Push(x T) {
+	d.data = append(d.data, x)
+	d.up(len(d.data) - 1)
+	if debug {
+		debugVerifyf(d, "Push(%v)", x)
+	}
+}
+
+// PeekMin returns the smallest element without removing it.
+func (d *IntervalHeap[T]) PeekMin() T {
+	checkEmpty(len(d.data), "PeekMin")
+	return d.data[0]
+}
+
+// PeekMax returns the largest element without removing it.
+func (d *IntervalHeap[T]) PeekMax() T {
+	checkEmpty(len(d.data), "PeekMax")
+	if len(d.data) == 1 {
+		return d.data[0]
+	}
+	return d.data[1]
+}
+
+// PopMin removes and returns the smallest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IntervalHeap[T]) PopMin() T {
+	checkEmpty(len(d.data), "PopMin")
+	x := d.pop(0)
+	if len(d.data) > 0 {
+		d.downMin(0)
+	}
+	if debug {
+		debugVerifyf(d, "PopMin() = %v", x)
+	}
+	return x
+}
+
+// PopMax removes and returns the largest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *IntervalHeap[T]) PopMax() T {
+	checkEmpty(len(d.data), "PopMax")
+	var x T
+	if len(d.data) == 1 {
+		x = d.pop(0)
+	} else {
+		x = d.pop(1)
+		if len(d.data) > 1 {
+			d.downMax(1)
+		}
+	}
+	if debug {
+		debugVerifyf(d, "PopMax() = %v", x)
+	}
+	return x
+}
+
+// up moves the last element, at index i, to its place
+func (d *IntervalHeap[T]) up(i int) {
+	if i%2 == 1 {
+		// the element completes the pair of the last node
+		if d.cmp(d.data[i], d.data[i-1]) < 0 {
+			d.swap(i, i-1)
+			d.upMin(i - 1)
+		} else {
+			d.upMax(i)
+		}
+		return
+	}
+	if i == 0 {
+		return
+	}
+	// the element is alone in a new node, so it is both its lo and its hi
+	p := (i/2 - 1) / 2
+	if d.cmp(d.data[i], d.data[2*p]) < 0 {
+		d.upMin(i)
+	} else if d.cmp(d.data[2*p+1], d.data[i]) < 0 {
+		d.upMax(i)
+	}
+}
+
+// pop replaces the element at index i with the last element and returns it
+func (d *IntervalHeap[T]) pop(i int) T {
+	l := len(d.data) - 1
+	x := d.data[i]
+	d.data[i] = d.data[l]
+	var zero T
+	d.data[l] = zero
+	d.data = d.data[:l]
+	return x
+}
+
+func (d *IntervalHeap[T]) swap(i, j int) {
+	d.data[i], d.data[j] = d.data[j], d.data[i]
+}
+
+// upMin moves the lo element at index i up towards the root
+func (d *IntervalHeap[T]) upMin(i int) {
+	for i > 1 {
+		p := (i/2 - 1) / 2 * 2
+		if d.cmp(d.data[i], d.data[p]) >= 0 {
+			return
+		}
+		d.swap(i, p)
+		i = p
+	}
+}
+
+// upMax moves the hi element at index i up towards the root.  i may also
+// be the index of a last node holding a single element.
+func (d *IntervalHeap[T]) upMax(i int) {
+	for i > 1 {
+		p := (i/2-1)/2*2 + 1
+		if d.cmp(d.data[p], d.data[i]) >= 0 {
+			return
+		}
+		d.swap(i, p)
+		i = p
+	}
+}
+
+// downMin moves the lo element at index i down until it is no greater than
+// the lo elements of its children
+func (d *IntervalHeap[T]) downMin(i int) {
+	n := len(d.data)
+	for {
+		if i+1 < n && d.cmp(d.data[i+1], d.data[i]) < 0 {
+			d.swap(i, i+1)
+		}
+		c := 2*i + 2
+		if c >= n {
+			return
+		}
+		if c+2 < n && d.cmp(d.data[c+2], d.data[c]) < 0 {
+			c += 2
+		}
+		if d.cmp(d.data[c], d.data[i]) >= 0 {
+			return
+		}
+		d.swap(i, c)
+		i = c
+	}
+}
+
+// downMax moves the hi element at index i down until it is no less than
+// the hi elements of its children
+func (d *IntervalHeap[T]) downMax(i int) {
+	n := len(d.data)
+	for {
+		if d.cmp(d.data[i], d.data[i-1]) < 0 {
+			d.swap(i, i-1)
+		}
+		c := 2 * i
+		if c >= n {
+			return
+		}
+		// the hi of a child holding a single element is its lo
+		m := min(c+1, n-1)
+		if c+2 < n {
+			if j := min(c+3, n-1); d.cmp(d.data[m], d.data[j]) < 0 {
+				m = j
+			}
+		}
+		if d.cmp(d.data[m], d.data[i]) <= 0 {
+			return
+		}
+		d.swap(i, m)
+		if m%2 == 0 {
+			return
+		}
+		i = m
+	}
+}
+
+// ilevel returns the level of the node holding the element at index i
+func ilevel(i int) int {
+	return bits.Len(uint(i/2+1)) - 1
+}
+
+// Verify checks the ordering of the heap and returns an *InvariantError
+// describing the first violation, or nil if the heap is valid.  Levels are
+// those of the nodes holding the elements, and a hi element that is less
+// than the lo element of its own node is reported as its descendant.
+// Time complexity is O(n), where n = d.Len()
+func (d *IntervalHeap[T]) Verify() error {
+	for i := 1; i < len(d.data); i++ {
+		if i%2 == 1 && d.cmp(d.data[i], d.data[i-1]) < 0 {
+			return d.invariantError(i-1, i, true)
+		}
+		if i < 2 {
+			continue
+		}
+		p := (i/2 - 1) / 2 * 2
+		if d.cmp(d.data[i], d.data[p]) < 0 {
+			return d.invariantError(p, i, true)
+		}
+		if d.cmp(d.data[p+1], d.data[i]) < 0 {
+			return d.invariantError(p+1, i, false)
+		}
+	}
+	return nil
+}
+
+func (d *IntervalHeap[T]) invariantError(i, j int, min bool) error {
+	return &InvariantError{
+		Ancestor:        i,
+		Descendant:      j,
+		AncestorLevel:   ilevel(i),
+		DescendantLevel: ilevel(j),
+		Min:             min,
+	}
+}
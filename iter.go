@@ -0,0 +1,198 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"container/heap"
+	"iter"
+	"sort"
+)
+
+// DrainMin returns an iterator that pops the elements of h in ascending
+// order.  Elements are popped lazily, so stopping the iteration early
+// leaves the remaining elements in h.
+func DrainMin(h heap.Interface) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for h.Len() > 0 {
+			if !yield(Pop(h)) {
+				return
+			}
+		}
+	}
+}
+
+// DrainMax returns an iterator that pops the elements of h in descending
+// order.  See DrainMin().
+func DrainMax(h heap.Interface) iter.Seq[any] {
+	return func(yield func(any) bool) {
+		for h.Len() > 0 {
+			if !yield(PopMax(h)) {
+				return
+			}
+		}
+	}
+}
+
+// Ascending returns an iterator over the indexes of the k smallest elements
+// of h in ascending order of their elements.  h is not changed, and must not
+// be changed during the iteration.
+// Time complexity is O(k log k)
+func Ascending(h heap.Interface, k int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		walk(h, h.Len(), true, k, yield)
+	}
+}
+
+// Descending returns an iterator over the indexes of the k largest elements
+// of h in descending order of their elements.  See Ascending().
+func Descending(h heap.Interface, k int) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		walk(h, h.Len(), false, k, yield)
+	}
+}
+
+// walk yields the indexes of up to k of the first l elements of h in order,
+// from the min side or the max side.
+//
+// Every element is at least its nearest ancestor on a min level, and at
+// most its nearest ancestor on a max level.  The walk keeps a frontier of
+// elements whose nearest ancestor on the side being walked has already been
+// yielded, and the next element in order is always the first of the
+// frontier.
+func walk(h sort.Interface, l int, min bool, k int, yield func(int) bool) {
+	if l == 0 || k <= 0 {
+		return
+	}
+	if k > l {
+		k = l
+	}
+	// every yielded element adds at most six elements to the frontier
+	n := 6*k + 3
+	if n > l {
+		n = l
+	}
+	f := &frontier{h: h, min: min, idx: make([]int, 0, n)}
+	f.push(0)
+	if !min {
+		for i := 1; i < 3 && i < l; i++ {
+			f.push(i)
+		}
+	}
+	for ; k > 0 && len(f.idx) > 0; k-- {
+		i := f.pop()
+		if !yield(i) {
+			return
+		}
+		if isMinHeap(i) != min {
+			continue
+		}
+		for j := hlchild(i); j < hlchild(i)+2 && j < l; j++ {
+			f.push(j)
+		}
+		for j := lchild(i); j < lchild(i)+4 && j < l; j++ {
+			f.push(j)
+		}
+	}
+}
+
+// frontier is a deheap of indexes into h, ordered by the elements of h on
+// the side being walked
+type frontier struct {
+	h   sort.Interface
+	min bool
+	idx []int
+}
+
+func (f *frontier) Len() int           { return len(f.idx) }
+func (f *frontier) Less(i, j int) bool { return less(f.h, f.min, f.idx[i], f.idx[j]) }
+func (f *frontier) Swap(i, j int)      { f.idx[i], f.idx[j] = f.idx[j], f.idx[i] }
+
+func (f *frontier) push(i int) {
+	f.idx = append(f.idx, i)
+	j := len(f.idx) - 1
+	bubbleup(f, isMinHeap(j), j)
+}
+
+func (f *frontier) pop() int {
+	l := len(f.idx) - 1
+	i := f.idx[0]
+	f.idx[0] = f.idx[l]
+	f.idx = f.idx[:l]
+	bubbledown(f, l, true, 0)
+	return i
+}
+
+// All returns an iterator over the elements of the deheap in heap order,
+// the order of the indexes used by At, Remove and Fix.
+func (d *Deheap[T]) All() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for _, x := range d.data {
+			if !yield(x) {
+				return
+			}
+		}
+	}
+}
+
+// Ascending returns an iterator over the k smallest elements in ascending
+// order.  The deheap is not changed, and must not be changed during the
+// iteration.
+// Time complexity is O(k log k)
+func (d *Deheap[T]) Ascending(k int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walk(d.slice(), len(d.data), true, k, func(i int) bool { return yield(d.data[i]) })
+	}
+}
+
+// Descending returns an iterator over the k largest elements in descending
+// order.  See Ascending().
+func (d *Deheap[T]) Descending(k int) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		walk(d.slice(), len(d.data), false, k, func(i int) bool { return yield(d.data[i]) })
+	}
+}
+
+// DrainMin returns an iterator that pops the elements in ascending order.
+// See DrainMin().
+func (d *Deheap[T]) DrainMin() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(d.data) > 0 {
+			if !yield(d.PopMin()) {
+				return
+			}
+		}
+	}
+}
+
+// DrainMax returns an iterator that pops the elements in descending order.
+// See DrainMax().
+func (d *Deheap[T]) DrainMax() iter.Seq[T] {
+	return func(yield func(T) bool) {
+		for len(d.data) > 0 {
+			if !yield(d.PopMax()) {
+				return
+			}
+		}
+	}
+}
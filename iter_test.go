@@ -0,0 +1,142 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"reflect"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestDrain(t *testing.T) {
+
+	h := &IntHeap{5, 1, 9, 3, 7}
+	Init(h)
+	out := []int{}
+	for x := range DrainMin(h) {
+		out = append(out, x.(int))
+		if len(out) == 2 {
+			break
+		}
+	}
+	for x := range DrainMax(h) {
+		out = append(out, x.(int))
+	}
+	if !reflect.DeepEqual(out, []int{1, 3, 9, 7, 5}) {
+		t.Fatalf("unexpected value: %v", out)
+	}
+	if h.Len() != 0 {
+		t.Fatalf("unexpected value: %d", h.Len())
+	}
+
+	d := New(intCmp)
+	for _, x := range []int{5, 1, 9, 3, 7} {
+		d.Push(x)
+	}
+	if s := slices.Collect(d.DrainMax()); !reflect.DeepEqual(s, []int{9, 7, 5, 3, 1}) {
+		t.Fatalf("unexpected value: %v", s)
+	}
+
+}
+
+func TestAscending(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 500; k++ {
+		N := s.Intn(100)
+		h := &IntHeap{}
+		for i := 0; i < N; i++ {
+			Push(h, s.Intn(N/2+1))
+		}
+		h0 := append(IntHeap{}, *h...)
+		ref := append([]int{}, *h...)
+		sort.Ints(ref)
+
+		K := s.Intn(N + 2)
+		out := []int{}
+		for i := range Ascending(h, K) {
+			out = append(out, (*h)[i])
+		}
+		if len(out) != K && len(out) != N {
+			t.Fatalf("unexpected value: %d %d %d", len(out), K, N)
+		}
+		if !reflect.DeepEqual(out, ref[:len(out)]) {
+			t.Fatalf("unexpected value: %v %v", out, ref)
+		}
+
+		out = out[:0]
+		for i := range Descending(h, K) {
+			out = append(out, (*h)[i])
+		}
+		slices.Reverse(ref)
+		if !reflect.DeepEqual(out, ref[:len(out)]) {
+			t.Fatalf("unexpected value: %v %v", out, ref)
+		}
+
+		if !reflect.DeepEqual(h, &h0) {
+			t.Fatalf("unexpected value: %v %v", h, h0)
+		}
+	}
+
+}
+
+func TestDeheapIterators(t *testing.T) {
+
+	d := New(intCmp)
+	for _, x := range []int{5, 1, 9, 3, 7, 3} {
+		d.Push(x)
+	}
+	if s := slices.Collect(d.Ascending(4)); !reflect.DeepEqual(s, []int{1, 3, 3, 5}) {
+		t.Fatalf("unexpected value: %v", s)
+	}
+	if s := slices.Collect(d.Descending(2)); !reflect.DeepEqual(s, []int{9, 7}) {
+		t.Fatalf("unexpected value: %v", s)
+	}
+	if s := slices.Collect(d.All()); !reflect.DeepEqual(s, d.data) {
+		t.Fatalf("unexpected value: %v", s)
+	}
+	if s := slices.Collect(d.DrainMin()); !reflect.DeepEqual(s, []int{1, 3, 3, 5, 7, 9}) {
+		t.Fatalf("unexpected value: %v", s)
+	}
+
+}
+
+func BenchmarkAscending(b *testing.B) {
+
+	s := _newRand()
+	h := &IntHeap{}
+	for i := 0; i < 1<<16; i++ {
+		Push(h, s.Int())
+	}
+
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		for range Ascending(h, 100) {
+		}
+	}
+
+}
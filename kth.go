@@ -0,0 +1,131 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"container/heap"
+	"sort"
+)
+
+// KthMin returns the index of the kth smallest element of h, counting from
+// 1, so KthMin(h, 1) is 0.  h is not changed.  KthMin panics if k is not in
+// [1, h.Len()].
+// Time complexity is O(k log k)
+func KthMin(h heap.Interface, k int) int {
+	l := h.Len()
+	checkRank(k, l, "KthMin")
+	return kth(h, l, true, k)
+}
+
+// KthMax returns the index of the kth largest element of h, counting from
+// 1.  See KthMin().
+// Time complexity is O(k log k)
+func KthMax(h heap.Interface, k int) int {
+	l := h.Len()
+	checkRank(k, l, "KthMax")
+	return kth(h, l, false, k)
+}
+
+// SmallestK returns the indexes of the k smallest elements of h in
+// ascending order of their elements, or of all of them if h has fewer than
+// k elements.  h is not changed.
+// Time complexity is O(k log k)
+func SmallestK(h heap.Interface, k int) []int {
+	return firstK(h, h.Len(), true, k)
+}
+
+// LargestK returns the indexes of the k largest elements of h in descending
+// order of their elements.  See SmallestK().
+// Time complexity is O(k log k)
+func LargestK(h heap.Interface, k int) []int {
+	return firstK(h, h.Len(), false, k)
+}
+
+// kth returns the index of the kth of the first l elements of h from the
+// min side or the max side
+func kth(h sort.Interface, l int, min bool, k int) int {
+	i := -1
+	walk(h, l, min, k, func(j int) bool {
+		i = j
+		return true
+	})
+	return i
+}
+
+// firstK returns the indexes of up to k of the first l elements of h in
+// order from the min side or the max side
+func firstK(h sort.Interface, l int, min bool, k int) []int {
+	n := k
+	if n > l {
+		n = l
+	}
+	if n <= 0 {
+		return nil
+	}
+	r := make([]int, 0, n)
+	walk(h, l, min, n, func(j int) bool {
+		r = append(r, j)
+		return true
+	})
+	return r
+}
+
+// KthMin returns the kth smallest element, counting from 1.  See KthMin().
+// Time complexity is O(k log k)
+func (d *Deheap[T]) KthMin(k int) T {
+	checkRank(k, len(d.data), "KthMin")
+	return d.data[kth(d.slice(), len(d.data), true, k)]
+}
+
+// KthMax returns the kth largest element, counting from 1.  See KthMax().
+// Time complexity is O(k log k)
+func (d *Deheap[T]) KthMax(k int) T {
+	checkRank(k, len(d.data), "KthMax")
+	return d.data[kth(d.slice(), len(d.data), false, k)]
+}
+
+// SmallestK returns the k smallest elements in ascending order.  See
+// SmallestK().
+// Time complexity is O(k log k)
+func (d *Deheap[T]) SmallestK(k int) []T {
+	return d.elements(firstK(d.slice(), len(d.data), true, k))
+}
+
+// LargestK returns the k largest elements in descending order.  See
+// LargestK().
+// Time complexity is O(k log k)
+func (d *Deheap[T]) LargestK(k int) []T {
+	return d.elements(firstK(d.slice(), len(d.data), false, k))
+}
+
+func (d *Deheap[T]) elements(idx []int) []T {
+	if idx == nil {
+		return nil
+	}
+	r := make([]T, len(idx))
+	for i, j := range idx {
+		r[i] = d.data[j]
+	}
+	return r
+}
@@ -0,0 +1,115 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestKth(t *testing.T) {
+
+	s := _newRand()
+
+	for n := 1; n < 100; n++ {
+		h := &IntHeap{}
+		for i := 0; i < n; i++ {
+			Push(h, s.Intn(1000)*1000+i)
+		}
+		before := append(IntHeap{}, *h...)
+		sorted := append([]int{}, *h...)
+		sort.Ints(sorted)
+		for k := 1; k <= n; k++ {
+			if x := (*h)[KthMin(h, k)]; x != sorted[k-1] {
+				t.Fatalf("unexpected value: %d %d %d", k, x, sorted[k-1])
+			}
+			if x := (*h)[KthMax(h, k)]; x != sorted[n-k] {
+				t.Fatalf("unexpected value: %d %d %d", k, x, sorted[n-k])
+			}
+		}
+		k := s.Intn(n + 5)
+		smallest, largest := []int{}, []int{}
+		for _, i := range SmallestK(h, k) {
+			smallest = append(smallest, (*h)[i])
+		}
+		for _, i := range LargestK(h, k) {
+			largest = append(largest, (*h)[i])
+		}
+		m := min(k, n)
+		if !reflect.DeepEqual(smallest, sorted[:m]) || len(largest) != m {
+			t.Fatalf("unexpected value: %d %v %v", k, smallest, sorted[:m])
+		}
+		for i, x := range largest {
+			if x != sorted[n-1-i] {
+				t.Fatalf("unexpected value: %d %v", k, largest)
+			}
+		}
+		if !reflect.DeepEqual(*h, before) {
+			t.Fatalf("unexpected value: %v %v", *h, before)
+		}
+	}
+
+	h := &IntHeap{1, 3, 2}
+	if r := SmallestK(h, 0); r != nil {
+		t.Fatalf("unexpected value: %v", r)
+	}
+	for _, k := range []int{0, 4} {
+		func() {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, ErrIndexOutOfRange) {
+					t.Fatalf("unexpected value: %v", err)
+				}
+			}()
+			KthMax(h, k)
+		}()
+	}
+
+}
+
+func TestDeheapKth(t *testing.T) {
+
+	d := New(intCmp)
+	for _, x := range []int{5, 3, 8, 1, 9, 2, 7} {
+		d.Push(x)
+	}
+	if x := d.KthMin(3); x != 3 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if x := d.KthMax(2); x != 8 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if r := d.SmallestK(4); !reflect.DeepEqual(r, []int{1, 2, 3, 5}) {
+		t.Fatalf("unexpected value: %v", r)
+	}
+	if r := d.LargestK(10); !reflect.DeepEqual(r, []int{9, 8, 7, 5, 3, 2, 1}) {
+		t.Fatalf("unexpected value: %v", r)
+	}
+	if d.Len() != 7 {
+		t.Fatalf("unexpected value: %d", d.Len())
+	}
+
+}
@@ -0,0 +1,153 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+// Median tracks the median of a changing collection of elements.
+//
+// The elements are split between two deheaps: the lower half, whose max side
+// holds the lower median, and the upper half, whose min side holds the upper
+// median.  The other sides of the two deheaps hold the smallest and largest
+// elements, so both ends and the center of the collection are available in
+// O(1).
+type Median[T any] struct {
+	// lo holds the same number of elements as hi, or one more
+	lo IndexedDeheap[T]
+	hi IndexedDeheap[T]
+}
+
+// NewMedian returns an empty Median ordered by cmp.  See New().
+func NewMedian[T any](cmp func(a, b T) int) *Median[T] {
+	return &Median[T]{
+		lo: IndexedDeheap[T]{cmp: cmp},
+		hi: IndexedDeheap[T]{cmp: cmp},
+	}
+}
+
+// Len returns the number of elements.
+func (m *Median[T]) Len() int {
+	return m.lo.Len() + m.hi.Len()
+}
+
+// Add adds x and returns a handle that can be used to remove it.
+// Time complexity is O(log n), where n = m.Len()
+func (m *Median[T]) Add(x T) *Handle[T] {
+	h := &Handle[T]{value: x}
+	if m.lo.Len() == 0 || m.lo.cmp(x, m.lo.PeekMax()) <= 0 {
+		m.lo.push(h)
+	} else {
+		m.hi.push(h)
+	}
+	m.balance()
+	return h
+}
+
+// Remove removes the element h refers to and returns it.
+// Time complexity is O(log n), where n = m.Len()
+func (m *Median[T]) Remove(h *Handle[T]) T {
+	switch h.owner {
+	case &m.lo:
+		m.lo.remove(h.index)
+	case &m.hi:
+		m.hi.remove(h.index)
+	default:
+		panic("deheap: handle does not refer to an element of this median")
+	}
+	m.balance()
+	return h.value
+}
+
+// Contains reports whether h refers to an element of m.
+func (m *Median[T]) Contains(h *Handle[T]) bool {
+	return h.owner == &m.lo || h.owner == &m.hi
+}
+
+// Median returns the lower and upper medians.  They are the same element
+// when m.Len() is odd.
+func (m *Median[T]) Median() (lower, upper T) {
+	return m.LowerMedian(), m.UpperMedian()
+}
+
+// LowerMedian returns the element at index (n-1)/2 of the sorted elements,
+// where n = m.Len().
+func (m *Median[T]) LowerMedian() T {
+	return m.lo.PeekMax()
+}
+
+// UpperMedian returns the element at index n/2 of the sorted elements, where
+// n = m.Len().
+func (m *Median[T]) UpperMedian() T {
+	if m.lo.Len() > m.hi.Len() {
+		return m.lo.PeekMax()
+	}
+	return m.hi.PeekMin()
+}
+
+// Min returns the smallest element.
+func (m *Median[T]) Min() T {
+	return m.lo.PeekMin()
+}
+
+// Max returns the largest element.
+func (m *Median[T]) Max() T {
+	if m.hi.Len() == 0 {
+		return m.lo.PeekMax()
+	}
+	return m.hi.PeekMax()
+}
+
+// KthBelow returns the kth element counting down from the lower median in
+// sorted order, counting from 1 as KthMax() does, so KthBelow(1) is the
+// lower median.  KthBelow panics if k is not in [1, (m.Len()+1)/2].
+// Time complexity is O(k log k)
+func (m *Median[T]) KthBelow(k int) T {
+	checkRank(k, m.lo.Len(), "KthBelow")
+	return m.lo.data[kth(m.lo.slice(), m.lo.Len(), false, k)].value
+}
+
+// KthAbove returns the kth element counting up from the upper median in
+// sorted order, counting from 1 as KthMin() does, so KthAbove(1) is the
+// upper median.  KthAbove panics if k is not in [1, (m.Len()+1)/2].
+// Time complexity is O(k log k)
+func (m *Median[T]) KthAbove(k int) T {
+	if m.lo.Len() > m.hi.Len() {
+		checkRank(k, m.hi.Len()+1, "KthAbove")
+		if k == 1 {
+			return m.lo.PeekMax()
+		}
+		k--
+	} else {
+		checkRank(k, m.hi.Len(), "KthAbove")
+	}
+	return m.hi.data[kth(m.hi.slice(), m.hi.Len(), true, k)].value
+}
+
+// balance moves an element between the halves when their sizes differ by
+// more than the lower half allows
+func (m *Median[T]) balance() {
+	if m.lo.Len() > m.hi.Len()+1 {
+		m.hi.push(m.lo.remove(maxIndex(m.lo.slice(), m.lo.Len())))
+	} else if m.hi.Len() > m.lo.Len() {
+		m.lo.push(m.hi.remove(0))
+	}
+}
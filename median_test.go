@@ -0,0 +1,124 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"testing"
+)
+
+func TestMedian(t *testing.T) {
+
+	m := NewMedian(intCmp)
+	for _, x := range []int{5, 1, 9} {
+		m.Add(x)
+	}
+	if lo, hi := m.Median(); lo != 5 || hi != 5 {
+		t.Fatalf("unexpected value: %d %d", lo, hi)
+	}
+	h := m.Add(7)
+	if lo, hi := m.Median(); lo != 5 || hi != 7 {
+		t.Fatalf("unexpected value: %d %d", lo, hi)
+	}
+	if x := m.Remove(h); x != 7 || m.Contains(h) {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if m.Min() != 1 || m.Max() != 9 || m.Len() != 3 {
+		t.Fatalf("unexpected value: %d %d %d", m.Min(), m.Max(), m.Len())
+	}
+	lo, hi := append([]*Handle[int]{}, m.lo.data...), append([]*Handle[int]{}, m.hi.data...)
+	if m.KthBelow(1) != 5 || m.KthBelow(2) != 1 || m.KthAbove(1) != 5 || m.KthAbove(2) != 9 {
+		t.Fatalf("unexpected value: %d %d %d %d", m.KthBelow(1), m.KthBelow(2), m.KthAbove(1), m.KthAbove(2))
+	}
+	if !slices.Equal(m.lo.data, lo) || !slices.Equal(m.hi.data, hi) {
+		t.Fatalf("unexpected value: %v %v", m.lo.data, m.hi.data)
+	}
+	for _, k := range []int{0, 3} {
+		func() {
+			defer func() {
+				err, ok := recover().(error)
+				if !ok || !errors.Is(err, ErrIndexOutOfRange) ||
+					err.Error() != fmt.Sprintf("deheap: index out of range: KthAbove rank %d with length 2", k) {
+					t.Fatalf("unexpected value: %v", err)
+				}
+			}()
+			m.KthAbove(k)
+		}()
+	}
+
+}
+
+func TestMedianRandom(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 100; k++ {
+		m := NewMedian(intCmp)
+		hs := []*Handle[int]{}
+		for i := 0; i < 300; i++ {
+			if len(hs) > 0 && s.Intn(3) == 0 {
+				j := s.Intn(len(hs))
+				m.Remove(hs[j])
+				hs = append(hs[:j], hs[j+1:]...)
+			} else {
+				hs = append(hs, m.Add(s.Intn(100)))
+			}
+			if len(hs) == 0 {
+				continue
+			}
+			ref := []int{}
+			for _, h := range hs {
+				if !m.Contains(h) {
+					t.Fatalf("unexpected value")
+				}
+				ref = append(ref, h.Value())
+			}
+			sort.Ints(ref)
+			n := len(ref)
+			if x := m.LowerMedian(); x != ref[(n-1)/2] {
+				t.Fatalf("unexpected value: %d %d", x, ref[(n-1)/2])
+			}
+			if x := m.UpperMedian(); x != ref[n/2] {
+				t.Fatalf("unexpected value: %d %d", x, ref[n/2])
+			}
+			if m.Min() != ref[0] || m.Max() != ref[n-1] {
+				t.Fatalf("unexpected value: %d %d", m.Min(), m.Max())
+			}
+			for j := 0; j <= (n-1)/2 && j < 4; j++ {
+				if x := m.KthBelow(j + 1); x != ref[(n-1)/2-j] {
+					t.Fatalf("unexpected value: %d %d %d", j, x, ref[(n-1)/2-j])
+				}
+			}
+			for j := 0; n/2+j < n && j < 4; j++ {
+				if x := m.KthAbove(j + 1); x != ref[n/2+j] {
+					t.Fatalf("unexpected value: %d %d %d", j, x, ref[n/2+j])
+				}
+			}
+		}
+	}
+
+}
@@ -0,0 +1,203 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"sort"
+	"testing"
+)
+
+func TestQuadLevel(t *testing.T) {
+	for i, want := range []int{0, 1, 1, 1, 1, 2, 2, 2} {
+		if x := qlevel(i); x != want {
+			t.Fatalf("unexpected value: %d %d", i, x)
+		}
+	}
+	if x := qlevel(20); x != 2 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if x := qlevel(21); x != 3 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if x := qparent(qgrandchild(7)); x != qchild(7) {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if x := qparent(qparent(qgrandchild(7) + 15)); x != 7 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+}
+
+func TestQuadDeheap(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		d := NewQuad(intCmp)
+		ref := []int{}
+		for i := 0; i < 300; i++ {
+			switch op := s.Intn(8); {
+			case op < 3 || len(ref) == 0:
+				x := s.Intn(100)
+				d.Push(x)
+				ref = append(ref, x)
+			case op == 3:
+				if x := d.PopMin(); x != ref[0] {
+					t.Fatalf("unexpected value: %d %d", x, ref[0])
+				}
+				ref = ref[1:]
+			case op == 4:
+				if x := d.PopMax(); x != ref[len(ref)-1] {
+					t.Fatalf("unexpected value: %d %d", x, ref[len(ref)-1])
+				}
+				ref = ref[:len(ref)-1]
+			case op == 5:
+				j := s.Intn(d.Len())
+				x := d.Remove(j)
+				k := sort.SearchInts(ref, x)
+				ref = append(ref[:k], ref[k+1:]...)
+			case op == 6:
+				x := s.Intn(100)
+				if y := d.ReplaceMax(x); y != ref[len(ref)-1] {
+					t.Fatalf("unexpected value: %d %d", y, ref[len(ref)-1])
+				}
+				ref[len(ref)-1] = x
+			default:
+				j := s.Intn(d.Len())
+				k := sort.SearchInts(ref, d.At(j))
+				x := s.Intn(100)
+				d.data[j] = x
+				d.Fix(j)
+				ref[k] = x
+			}
+			sort.Ints(ref)
+			if err := d.Verify(); err != nil {
+				t.Fatalf("unexpected value: %v %v", err, d.data)
+			}
+			if d.Len() != len(ref) {
+				t.Fatalf("unexpected value: %d %d", d.Len(), len(ref))
+			}
+			if len(ref) > 0 && (d.PeekMin() != ref[0] || d.PeekMax() != ref[len(ref)-1]) {
+				t.Fatalf("unexpected value: %d %d", d.PeekMin(), d.PeekMax())
+			}
+		}
+	}
+
+}
+
+func TestQuadDeheapMeld(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		d0 := NewQuad(intCmp)
+		d1 := NewQuad(intCmp)
+		n := 0
+		for i := s.Intn(200); i > 0; i-- {
+			d0.Push(s.Intn(100))
+			n++
+		}
+		for i := s.Intn(200); i > 0; i-- {
+			d1.Push(s.Intn(100))
+			n++
+		}
+		d0.Meld(d1)
+		if err := d0.Verify(); err != nil || d0.Len() != n || d1.Len() != 0 {
+			t.Fatalf("unexpected value: %v %d %d", err, d0.Len(), d1.Len())
+		}
+	}
+
+	d := NewQuad(intCmp)
+	for i := 0; i < 10; i++ {
+		d.Push(i)
+	}
+	d.Meld(d)
+	if err := d.Verify(); err != nil || d.Len() != 10 || d.PeekMin() != 0 || d.PeekMax() != 9 {
+		t.Fatalf("unexpected value: %v %v", err, d.data)
+	}
+
+}
+
+// The bubbledown benchmarks replace the smallest element of a heap of
+// random numbers with another random number, which moves it down to one of
+// the lowest levels.  The 1e8 benchmarks need several GB of memory.
+
+func randInts(b *testing.B, n int) []int {
+	s := _newRand()
+	r := make([]int, n)
+	for i := range r {
+		r[i] = s.Int()
+	}
+	return r
+}
+
+func benchmarkBubbledownHeap(b *testing.B, n int) {
+	if n > 1e6 && testing.Short() {
+		b.Skip("skipping large heap in short mode")
+	}
+	h := IntHeap(randInts(b, n))
+	Init(&h)
+	r := randInts(b, 1<<16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ReplaceMin(&h, r[i&(1<<16-1)])
+	}
+}
+
+func benchmarkBubbledownDeheap(b *testing.B, n int) {
+	if n > 1e6 && testing.Short() {
+		b.Skip("skipping large heap in short mode")
+	}
+	d := New(intCmp)
+	d.data = randInts(b, n)
+	heapify(d.slice(), n)
+	r := randInts(b, 1<<16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.ReplaceMin(r[i&(1<<16-1)])
+	}
+}
+
+func benchmarkBubbledownQuad(b *testing.B, n int) {
+	if n > 1e6 && testing.Short() {
+		b.Skip("skipping large heap in short mode")
+	}
+	d := NewQuad(intCmp)
+	d.data = randInts(b, n)
+	d.heapify()
+	r := randInts(b, 1<<16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		d.ReplaceMin(r[i&(1<<16-1)])
+	}
+}
+
+func BenchmarkBubbledownHeap1e3(b *testing.B)   { benchmarkBubbledownHeap(b, 1e3) }
+func BenchmarkBubbledownHeap1e6(b *testing.B)   { benchmarkBubbledownHeap(b, 1e6) }
+func BenchmarkBubbledownHeap1e8(b *testing.B)   { benchmarkBubbledownHeap(b, 1e8) }
+func BenchmarkBubbledownDeheap1e3(b *testing.B) { benchmarkBubbledownDeheap(b, 1e3) }
+func BenchmarkBubbledownDeheap1e6(b *testing.B) { benchmarkBubbledownDeheap(b, 1e6) }
+func BenchmarkBubbledownDeheap1e8(b *testing.B) { benchmarkBubbledownDeheap(b, 1e8) }
+func BenchmarkBubbledownQuad1e3(b *testing.B)   { benchmarkBubbledownQuad(b, 1e3) }
+func BenchmarkBubbledownQuad1e6(b *testing.B)   { benchmarkBubbledownQuad(b, 1e6) }
+func BenchmarkBubbledownQuad1e8(b *testing.B)   { benchmarkBubbledownQuad(b, 1e8) }
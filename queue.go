@@ -0,0 +1,179 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClosed is returned by the operations of a Queue that has been closed.
+var ErrClosed = errors.New("deheap: queue closed")
+
+// Queue is a doubly ended priority queue that is safe for concurrent use by
+// multiple goroutines.  Pops block until an element is available and, when
+// the queue has a capacity, pushes block until there is room.
+type Queue[T any] struct {
+	mu       sync.Mutex
+	d        Deheap[T]
+	capacity int
+	closed   bool
+	// notEmpty and notFull are created by waiting goroutines and closed to
+	// wake all of them when the queue changes
+	notEmpty chan struct{}
+	notFull  chan struct{}
+}
+
+// NewQueue returns an empty Queue ordered by cmp.  A capacity greater than
+// zero limits the number of elements in the queue; a capacity of zero means
+// no limit.  See New().
+func NewQueue[T any](capacity int, cmp func(a, b T) int) *Queue[T] {
+	if capacity < 0 {
+		panic("deheap: negative capacity")
+	}
+	return &Queue[T]{d: Deheap[T]{cmp: cmp}, capacity: capacity}
+}
+
+// Len returns the number of elements in the queue.
+func (q *Queue[T]) Len() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	return q.d.Len()
+}
+
+// Push adds x to the queue, waiting for room if the queue is full.  Push
+// returns ErrClosed if the queue is closed, or the context's error if ctx
+// is done before x is added.
+func (q *Queue[T]) Push(ctx context.Context, x T) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for {
+		if q.closed {
+			return ErrClosed
+		}
+		if q.capacity == 0 || q.d.Len() < q.capacity {
+			break
+		}
+		if err := q.wait(ctx, &q.notFull); err != nil {
+			return err
+		}
+	}
+	q.d.Push(x)
+	broadcast(&q.notEmpty)
+	return nil
+}
+
+// PopMin removes and returns the smallest element, waiting for one if the
+// queue is empty.  Elements left in a closed queue are still returned;
+// PopMin returns ErrClosed once a closed queue is empty, or the context's
+// error if ctx is done before an element is available.
+func (q *Queue[T]) PopMin(ctx context.Context) (T, error) {
+	return q.pop(ctx, true)
+}
+
+// PopMax removes and returns the largest element, waiting for one if the
+// queue is empty.  See PopMin().
+func (q *Queue[T]) PopMax(ctx context.Context) (T, error) {
+	return q.pop(ctx, false)
+}
+
+// TryPopMin removes and returns the smallest element if the queue is not
+// empty.
+func (q *Queue[T]) TryPopMin() (T, bool) {
+	return q.tryPop(true)
+}
+
+// TryPopMax removes and returns the largest element if the queue is not
+// empty.
+func (q *Queue[T]) TryPopMax() (T, bool) {
+	return q.tryPop(false)
+}
+
+// Close closes the queue and wakes all waiting goroutines.  Pushes to a
+// closed queue fail, while pops drain the elements that remain.
+func (q *Queue[T]) Close() {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	q.closed = true
+	broadcast(&q.notEmpty)
+	broadcast(&q.notFull)
+}
+
+func (q *Queue[T]) pop(ctx context.Context, min bool) (x T, err error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	for q.d.Len() == 0 {
+		if q.closed {
+			return x, ErrClosed
+		}
+		if err = q.wait(ctx, &q.notEmpty); err != nil {
+			return x, err
+		}
+	}
+	return q.popLocked(min), nil
+}
+
+func (q *Queue[T]) tryPop(min bool) (x T, ok bool) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if q.d.Len() == 0 {
+		return x, false
+	}
+	return q.popLocked(min), true
+}
+
+func (q *Queue[T]) popLocked(min bool) (x T) {
+	if min {
+		x = q.d.PopMin()
+	} else {
+		x = q.d.PopMax()
+	}
+	broadcast(&q.notFull)
+	return x
+}
+
+// wait releases the lock until *c is closed or ctx is done
+func (q *Queue[T]) wait(ctx context.Context, c *chan struct{}) error {
+	if *c == nil {
+		*c = make(chan struct{})
+	}
+	ch := *c
+	q.mu.Unlock()
+	defer q.mu.Lock()
+	select {
+	case <-ch:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// broadcast wakes all goroutines waiting on *c
+func broadcast(c *chan struct{}) {
+	if *c != nil {
+		close(*c)
+		*c = nil
+	}
+}
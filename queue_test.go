@@ -0,0 +1,219 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestQueuePop(t *testing.T) {
+
+	q := NewQueue(0, intCmp)
+	ctx := context.Background()
+	for _, x := range []int{5, 1, 9, 3} {
+		if err := q.Push(ctx, x); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+	if x, err := q.PopMax(ctx); x != 9 || err != nil {
+		t.Fatalf("unexpected value: %d %v", x, err)
+	}
+	if x, err := q.PopMin(ctx); x != 1 || err != nil {
+		t.Fatalf("unexpected value: %d %v", x, err)
+	}
+	if x, ok := q.TryPopMin(); x != 3 || !ok {
+		t.Fatalf("unexpected value: %d %v", x, ok)
+	}
+	if x, ok := q.TryPopMax(); x != 5 || !ok {
+		t.Fatalf("unexpected value: %d %v", x, ok)
+	}
+	if _, ok := q.TryPopMax(); ok {
+		t.Fatalf("unexpected value")
+	}
+
+}
+
+func TestQueueBlocking(t *testing.T) {
+
+	q := NewQueue(0, intCmp)
+	ctx := context.Background()
+
+	c := make(chan int)
+	go func() {
+		x, _ := q.PopMin(ctx)
+		c <- x
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Push(ctx, 7)
+	if x := <-c; x != 7 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+
+	ctx1, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if _, err := q.PopMax(ctx1); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+}
+
+func TestQueueCapacity(t *testing.T) {
+
+	q := NewQueue(2, intCmp)
+	ctx := context.Background()
+	q.Push(ctx, 1)
+	q.Push(ctx, 2)
+
+	ctx1, cancel := context.WithTimeout(ctx, 10*time.Millisecond)
+	defer cancel()
+	if err := q.Push(ctx1, 3); err != context.DeadlineExceeded {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	c := make(chan error)
+	go func() {
+		c <- q.Push(ctx, 3)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	if x, _ := q.PopMin(ctx); x != 1 {
+		t.Fatalf("unexpected value: %d", x)
+	}
+	if err := <-c; err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if q.Len() != 2 {
+		t.Fatalf("unexpected value: %d", q.Len())
+	}
+
+}
+
+func TestQueueClose(t *testing.T) {
+
+	q := NewQueue(1, intCmp)
+	ctx := context.Background()
+	q.Push(ctx, 1)
+
+	var wg sync.WaitGroup
+	errs := make(chan error, 2)
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		errs <- q.Push(ctx, 2)
+	}()
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+	if err := <-errs; err != ErrClosed {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	if x, err := q.PopMin(ctx); x != 1 || err != nil {
+		t.Fatalf("unexpected value: %d %v", x, err)
+	}
+	if _, err := q.PopMin(ctx); err != ErrClosed {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if err := q.Push(ctx, 3); err != ErrClosed {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	q = NewQueue(0, intCmp)
+	wg.Add(2)
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+			_, err := q.PopMax(ctx)
+			errs <- err
+		}()
+	}
+	time.Sleep(10 * time.Millisecond)
+	q.Close()
+	wg.Wait()
+	for i := 0; i < 2; i++ {
+		if err := <-errs; err != ErrClosed {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+
+}
+
+func TestQueueConcurrent(t *testing.T) {
+
+	const P, N = 4, 1000
+
+	q := NewQueue(16, intCmp)
+	ctx := context.Background()
+
+	var producers, consumers sync.WaitGroup
+	var mu sync.Mutex
+	out := []int{}
+	for p := 0; p < P; p++ {
+		producers.Add(1)
+		go func(p int) {
+			defer producers.Done()
+			for i := 0; i < N; i++ {
+				if err := q.Push(ctx, p*N+i); err != nil {
+					t.Errorf("unexpected value: %v", err)
+				}
+			}
+		}(p)
+		consumers.Add(1)
+		go func(min bool) {
+			defer consumers.Done()
+			for {
+				var x int
+				var err error
+				if min {
+					x, err = q.PopMin(ctx)
+				} else {
+					x, err = q.PopMax(ctx)
+				}
+				if err != nil {
+					return
+				}
+				mu.Lock()
+				out = append(out, x)
+				mu.Unlock()
+			}
+		}(p%2 == 0)
+	}
+	producers.Wait()
+	q.Close()
+	consumers.Wait()
+
+	sort.Ints(out)
+	if len(out) != P*N {
+		t.Fatalf("unexpected value: %d", len(out))
+	}
+	for i, x := range out {
+		if x != i {
+			t.Fatalf("unexpected value: %d %d", i, x)
+		}
+	}
+
+}
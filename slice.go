@@ -0,0 +1,307 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"cmp"
+)
+
+// PushFunc pushes x onto the deheap s ordered by cmp and returns the
+// extended slice, in the way of append.  s must already be ordered as a
+// deheap, for instance by InitFunc.  See Push().
+// Time complexity is O(log n), where n = len(s)
+func PushFunc[S ~[]E, E any](s S, x E, cmp func(a, b E) int) S {
+	s = pushFunc(s, cmp, x)
+	if debug {
+		debugf(funcSlice[E]{s, cmp}, "PushFunc(%v)", x)
+	}
+	return s
+}
+
+// PopMinFunc removes the smallest element of the deheap s ordered by cmp and
+// returns the shortened slice and the element.  The element left over at the
+// end of s is zeroed.  See Pop().
+// Time complexity is O(log n), where n = len(s)
+func PopMinFunc[S ~[]E, E any](s S, cmp func(a, b E) int) (S, E) {
+	checkEmpty(len(s), "PopMinFunc")
+	s, x := removeFunc(s, cmp, 0)
+	if debug {
+		debugf(funcSlice[E]{s, cmp}, "PopMinFunc() = %v", x)
+	}
+	return s, x
+}
+
+// PopMaxFunc removes the largest element of the deheap s ordered by cmp.
+// See PopMinFunc() and PopMax().
+// Time complexity is O(log n), where n = len(s)
+func PopMaxFunc[S ~[]E, E any](s S, cmp func(a, b E) int) (S, E) {
+	checkEmpty(len(s), "PopMaxFunc")
+	s, x := removeFunc(s, cmp, maxIndexFunc(s, cmp))
+	if debug {
+		debugf(funcSlice[E]{s, cmp}, "PopMaxFunc() = %v", x)
+	}
+	return s, x
+}
+
+// InitFunc orders s as a deheap by cmp.  See Init().
+// Time complexity is O(n), where n = len(s)
+func InitFunc[S ~[]E, E any](s S, cmp func(a, b E) int) {
+	heapifyFunc(s, cmp)
+	if debug {
+		debugf(funcSlice[E]{s, cmp}, "InitFunc()")
+	}
+}
+
+// FixFunc re-establishes the ordering of the deheap s by cmp after the
+// element at index i has changed its value.  See Fix().
+// Time complexity is O(log n), where n = len(s)
+func FixFunc[S ~[]E, E any](s S, i int, cmp func(a, b E) int) {
+	checkIndex(i, len(s), "FixFunc")
+	fixFunc(s, cmp, i)
+	if debug {
+		debugf(funcSlice[E]{s, cmp}, "FixFunc(%d)", i)
+	}
+}
+
+// RemoveFunc removes the element at index i from the deheap s ordered by cmp.
+// See PopMinFunc() and Remove().
+// Time complexity is O(log n), where n = len(s)
+func RemoveFunc[S ~[]E, E any](s S, i int, cmp func(a, b E) int) (S, E) {
+	checkIndex(i, len(s), "RemoveFunc")
+	s, x := removeFunc(s, cmp, i)
+	if debug {
+		debugf(funcSlice[E]{s, cmp}, "RemoveFunc(%d) = %v", i, x)
+	}
+	return s, x
+}
+
+// PushOrdered pushes x onto the deheap s of ordered values.  See PushFunc().
+// Time complexity is O(log n), where n = len(s)
+func PushOrdered[S ~[]E, E cmp.Ordered](s S, x E) S {
+	s = pushFunc(s, cmp.Compare[E], x)
+	if debug {
+		debugf(funcSlice[E]{s, cmp.Compare[E]}, "PushOrdered(%v)", x)
+	}
+	return s
+}
+
+// PopMinOrdered removes the smallest element of the deheap s of ordered
+// values.  See PopMinFunc().
+// Time complexity is O(log n), where n = len(s)
+func PopMinOrdered[S ~[]E, E cmp.Ordered](s S) (S, E) {
+	checkEmpty(len(s), "PopMinOrdered")
+	s, x := removeFunc(s, cmp.Compare[E], 0)
+	if debug {
+		debugf(funcSlice[E]{s, cmp.Compare[E]}, "PopMinOrdered() = %v", x)
+	}
+	return s, x
+}
+
+// PopMaxOrdered removes the largest element of the deheap s of ordered
+// values.  See PopMaxFunc().
+// Time complexity is O(log n), where n = len(s)
+func PopMaxOrdered[S ~[]E, E cmp.Ordered](s S) (S, E) {
+	checkEmpty(len(s), "PopMaxOrdered")
+	s, x := removeFunc(s, cmp.Compare[E], maxIndexFunc(s, cmp.Compare[E]))
+	if debug {
+		debugf(funcSlice[E]{s, cmp.Compare[E]}, "PopMaxOrdered() = %v", x)
+	}
+	return s, x
+}
+
+// InitOrdered orders s as a deheap.  See InitFunc().
+// Time complexity is O(n), where n = len(s)
+func InitOrdered[S ~[]E, E cmp.Ordered](s S) {
+	heapifyFunc(s, cmp.Compare[E])
+	if debug {
+		debugf(funcSlice[E]{s, cmp.Compare[E]}, "InitOrdered()")
+	}
+}
+
+// FixOrdered re-establishes the ordering of the deheap s after the element
+// at index i has changed its value.  See FixFunc().
+// Time complexity is O(log n), where n = len(s)
+func FixOrdered[S ~[]E, E cmp.Ordered](s S, i int) {
+	checkIndex(i, len(s), "FixOrdered")
+	fixFunc(s, cmp.Compare[E], i)
+	if debug {
+		debugf(funcSlice[E]{s, cmp.Compare[E]}, "FixOrdered(%d)", i)
+	}
+}
+
+// RemoveOrdered removes the element at index i from the deheap s of ordered
+// values.  See RemoveFunc().
+// Time complexity is O(log n), where n = len(s)
+func RemoveOrdered[S ~[]E, E cmp.Ordered](s S, i int) (S, E) {
+	checkIndex(i, len(s), "RemoveOrdered")
+	s, x := removeFunc(s, cmp.Compare[E], i)
+	if debug {
+		debugf(funcSlice[E]{s, cmp.Compare[E]}, "RemoveOrdered(%d) = %v", i, x)
+	}
+	return s, x
+}
+
+// funcSlice adapts a slice ordered by a comparison function to the
+// sort.Interface checked by debugf.  It is not a pointer, so the operations
+// on all slices are recorded together.
+type funcSlice[E any] struct {
+	s   []E
+	cmp func(a, b E) int
+}
+
+func (f funcSlice[E]) Len() int           { return len(f.s) }
+func (f funcSlice[E]) Less(i, j int) bool { return f.cmp(f.s[i], f.s[j]) < 0 }
+func (f funcSlice[E]) Swap(i, j int)      { f.s[i], f.s[j] = f.s[j], f.s[i] }
+
+// The functions below are the deheap algorithms of deheap.go for slices
+// ordered by a comparison function, which the compiler can specialize for
+// the element type instead of calling Less and Swap through an interface.
+
+// lessFunc is less for a slice ordered by cmp
+func lessFunc[E any](s []E, cmp func(a, b E) int, min bool, i, j int) bool {
+	if min {
+		return cmp(s[i], s[j]) < 0
+	}
+	return cmp(s[j], s[i]) < 0
+}
+
+// bubbleupFunc is bubbleup for a slice ordered by cmp
+func bubbleupFunc[E any](s []E, cmp func(a, b E) int, min bool, i int) (q bool) {
+	j := parent(i)
+	for j >= 0 && lessFunc(s, cmp, min, i, j) {
+		q = true
+		s[i], s[j] = s[j], s[i]
+		i = j
+		j = parent(i)
+	}
+	if i == 0 {
+		return q
+	}
+	min = !min
+	j = hparent(i)
+	for j >= 0 && lessFunc(s, cmp, min, i, j) {
+		q = true
+		s[i], s[j] = s[j], s[i]
+		i = j
+		j = parent(i)
+	}
+	return q
+}
+
+// bubbledownFunc is bubbledown for a slice ordered by cmp
+func bubbledownFunc[E any](s []E, cmp func(a, b E) int, min bool, i int) {
+	l := len(s)
+	for {
+		// find min of children
+		j := hlchild(i)
+		if j >= l {
+			return
+		}
+		if j+1 < l && lessFunc(s, cmp, min, j+1, j) {
+			j++
+		}
+		// find min of the element at i, its children and grandchildren
+		v := i
+		if lessFunc(s, cmp, min, j, v) {
+			v = j
+		}
+		for k := lchild(i); k < lchild(i)+4 && k < l; k++ {
+			if lessFunc(s, cmp, min, k, v) {
+				v = k
+			}
+		}
+		if v == i {
+			return
+		}
+		s[i], s[v] = s[v], s[i]
+		if v == j {
+			return
+		}
+		if p := hparent(v); lessFunc(s, cmp, min, p, v) {
+			s[p], s[v] = s[v], s[p]
+		}
+		i = v
+	}
+}
+
+// maxIndexFunc is maxIndex for a slice ordered by cmp
+func maxIndexFunc[E any](s []E, cmp func(a, b E) int) int {
+	switch len(s) {
+	case 0, 1:
+		return 0
+	case 2:
+		return 1
+	}
+	if cmp(s[1], s[2]) < 0 {
+		return 2
+	}
+	return 1
+}
+
+// fixFunc is fix for a slice ordered by cmp
+func fixFunc[E any](s []E, cmp func(a, b E) int, i int) {
+	min := isMinHeap(i)
+	if i > 0 {
+		p := hparent(i)
+		if lessFunc(s, cmp, !min, i, p) {
+			s[i], s[p] = s[p], s[i]
+			bubbleupFunc(s, cmp, !min, p)
+			bubbledownFunc(s, cmp, min, i)
+			return
+		}
+	}
+	if !bubbleupFunc(s, cmp, min, i) {
+		bubbledownFunc(s, cmp, min, i)
+	}
+}
+
+// heapifyFunc is heapify for a slice ordered by cmp
+func heapifyFunc[E any](s []E, cmp func(a, b E) int) {
+	for i := len(s)/2 - 1; i >= 0; i-- {
+		bubbledownFunc(s, cmp, isMinHeap(i), i)
+	}
+}
+
+// pushFunc appends x to s and moves it up to its place
+func pushFunc[S ~[]E, E any](s S, cmp func(a, b E) int, x E) S {
+	s = append(s, x)
+	i := len(s) - 1
+	bubbleupFunc(s, cmp, isMinHeap(i), i)
+	return s
+}
+
+// removeFunc replaces the element at index i with the last element of s,
+// zeroes the last element and re-establishes the ordering of the rest
+func removeFunc[S ~[]E, E any](s S, cmp func(a, b E) int, i int) (S, E) {
+	l := len(s) - 1
+	x := s[i]
+	s[i] = s[l]
+	var zero E
+	s[l] = zero
+	s = s[:l]
+	if i != l {
+		fixFunc(s, cmp, i)
+	}
+	return s, x
+}
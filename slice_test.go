@@ -0,0 +1,149 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"errors"
+	"sort"
+	"testing"
+)
+
+func TestSliceFunc(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 200; k++ {
+		var h []int
+		ref := []int{}
+		for i := 0; i < 200; i++ {
+			switch op := s.Intn(6); {
+			case op < 2 || len(ref) == 0:
+				x := s.Intn(100)
+				h = PushFunc(h, x, intCmp)
+				ref = append(ref, x)
+			case op == 2:
+				var x int
+				h, x = PopMinFunc(h, intCmp)
+				if x != ref[0] {
+					t.Fatalf("unexpected value: %d %d", x, ref[0])
+				}
+				ref = ref[1:]
+			case op == 3:
+				var x int
+				h, x = PopMaxFunc(h, intCmp)
+				if x != ref[len(ref)-1] {
+					t.Fatalf("unexpected value: %d %d", x, ref[len(ref)-1])
+				}
+				ref = ref[:len(ref)-1]
+			case op == 4:
+				var x int
+				h, x = RemoveFunc(h, s.Intn(len(h)), intCmp)
+				k := sort.SearchInts(ref, x)
+				ref = append(ref[:k], ref[k+1:]...)
+			default:
+				j := s.Intn(len(h))
+				k := sort.SearchInts(ref, h[j])
+				x := s.Intn(100)
+				h[j] = x
+				FixFunc(h, j, intCmp)
+				ref[k] = x
+			}
+			sort.Ints(ref)
+			if len(h) != len(ref) {
+				t.Fatalf("unexpected value: %v %v", h, ref)
+			}
+			if x, y, ok := isDeheap(t, (*IntHeap)(&h)); !ok {
+				t.Fatalf("unexpected value: %d %d %v", x, y, h)
+			}
+		}
+	}
+
+}
+
+func TestSliceOrdered(t *testing.T) {
+
+	s := _newRand()
+
+	type names []string
+	var h names
+	for i := 0; i < 100; i++ {
+		h = append(h, string(rune('a'+s.Intn(26)))+string(rune('a'+s.Intn(26))))
+	}
+	ref := append([]string{}, h...)
+	sort.Strings(ref)
+	InitOrdered(h)
+	j := len(h) / 2
+	ref[sort.SearchStrings(ref, h[j])] = "zzz"
+	sort.Strings(ref)
+	h[j] = "zzz"
+	FixOrdered(h, j)
+	h = PushOrdered(h, "")
+	ref = append([]string{""}, ref...)
+	h, x := RemoveOrdered(h, 0)
+	if x != "" {
+		t.Fatalf("unexpected value: %q", x)
+	}
+	ref = ref[1:]
+	for len(ref) > 0 {
+		var x, y string
+		h, x = PopMinOrdered(h)
+		if x != ref[0] {
+			t.Fatalf("unexpected value: %q %q", x, ref[0])
+		}
+		ref = ref[1:]
+		if len(ref) == 0 {
+			break
+		}
+		h, y = PopMaxOrdered(h)
+		if y != ref[len(ref)-1] {
+			t.Fatalf("unexpected value: %q %q", y, ref[len(ref)-1])
+		}
+		ref = ref[:len(ref)-1]
+	}
+	if len(h) != 0 || cap(h) == 0 || h[:1][0] != "" {
+		t.Fatalf("unexpected value: %q", h[:cap(h)])
+	}
+
+	defer func() {
+		err, ok := recover().(error)
+		if !ok || !errors.Is(err, ErrEmpty) || err.Error() != "deheap: empty heap in PopMaxOrdered" {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}()
+	PopMaxOrdered(h)
+
+}
+
+func BenchmarkPushPopMinOrdered(b *testing.B) {
+
+	h := randInts(b, 1e5)
+	InitOrdered(h)
+	r := randInts(b, 1<<16)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		h = PushOrdered(h, r[i&(1<<16-1)])
+		h, _ = PopMinOrdered(h)
+	}
+
+}
@@ -0,0 +1,78 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"sort"
+)
+
+// Sort sorts data in ascending order with an in-place min-max heapsort.  The
+// sort is not stable.
+// Time complexity is O(n log n), where n = data.Len()
+func Sort(data sort.Interface) {
+	l := data.Len()
+	heapify(data, l)
+	popMax(data, l, l)
+}
+
+// PartialSortBoth moves the kLow smallest elements of data to its front in
+// ascending order, and the kHigh largest elements to its back, also in
+// ascending order, so that the largest element is last.  The elements in
+// between are left in no particular order.  When kLow+kHigh is at least
+// data.Len() all of data is sorted.
+// Time complexity is O(n + (kLow+kHigh) log n), where n = data.Len()
+func PartialSortBoth(data sort.Interface, kLow, kHigh int) {
+	if kLow < 0 || kHigh < 0 {
+		panic("deheap: negative count in PartialSortBoth")
+	}
+	l := data.Len()
+	if kLow+kHigh >= l {
+		Sort(data)
+		return
+	}
+	heapify(data, l)
+	popMax(data, l, kHigh)
+	l -= kHigh
+	// the smallest elements are popped to the end of the heap in descending
+	// order, and reversing the heap's elements moves them to the front in
+	// ascending order
+	for i := 0; i < kLow; i++ {
+		data.Swap(0, l-1-i)
+		bubbledown(data, l-1-i, true, 0)
+	}
+	for i := 0; i < kLow && i < l-1-i; i++ {
+		data.Swap(i, l-1-i)
+	}
+}
+
+// popMax moves the k largest of the first l elements of the heap data to
+// the end of them, in ascending order
+func popMax(data sort.Interface, l int, k int) {
+	for ; k > 0 && l > 1; k-- {
+		j := maxIndex(data, l)
+		l--
+		data.Swap(j, l)
+		bubbledown(data, l, false, j)
+	}
+}
@@ -0,0 +1,98 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+
+	s := _newRand()
+
+	for n := 0; n < 200; n++ {
+		h := make(IntHeap, n)
+		for i := range h {
+			h[i] = s.Intn(50)
+		}
+		ref := append([]int{}, h...)
+		sort.Ints(ref)
+		Sort(h)
+		if !reflect.DeepEqual([]int(h), ref) {
+			t.Fatalf("unexpected value: %v %v", h, ref)
+		}
+	}
+
+}
+
+func TestPartialSortBoth(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 1000; k++ {
+		n := s.Intn(100)
+		h := make(IntHeap, n)
+		for i := range h {
+			h[i] = s.Intn(50)
+		}
+		lo, hi := s.Intn(n/2+2), s.Intn(n/2+2)
+		ref := append([]int{}, h...)
+		sort.Ints(ref)
+		PartialSortBoth(h, lo, hi)
+		if lo+hi >= n {
+			lo, hi = n, 0
+		}
+		if !reflect.DeepEqual([]int(h[:lo]), ref[:lo]) || !reflect.DeepEqual([]int(h[n-hi:]), ref[n-hi:]) {
+			t.Fatalf("unexpected value: %d %d %v %v", lo, hi, h, ref)
+		}
+		mid := append([]int{}, h[lo:n-hi]...)
+		sort.Ints(mid)
+		if !reflect.DeepEqual(mid, ref[lo:n-hi]) {
+			t.Fatalf("unexpected value: %d %d %v %v", lo, hi, h, ref)
+		}
+	}
+
+}
+
+func BenchmarkSort(b *testing.B) {
+	r := randInts(b, 1e5)
+	h := make(IntHeap, len(r))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(h, r)
+		Sort(h)
+	}
+}
+
+func BenchmarkPartialSortBoth(b *testing.B) {
+	r := randInts(b, 1e6)
+	h := make(IntHeap, len(r))
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		copy(h, r)
+		PartialSortBoth(h, 100, 100)
+	}
+}
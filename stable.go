@@ -0,0 +1,117 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"math"
+	"sort"
+)
+
+// stableElement is an element of a Stable deheap with its insertion sequence
+// number
+type stableElement[T any] struct {
+	value T
+	seq   uint64
+}
+
+// Stable is a type-safe doubly ended heap that breaks ties between equal
+// elements by insertion order: among equal elements, PopMin returns the
+// element pushed first and PopMax the element pushed last.
+type Stable[T any] struct {
+	d   Deheap[stableElement[T]]
+	seq uint64
+}
+
+// NewStable returns an empty Stable ordered by cmp.  See New().
+func NewStable[T any](cmp func(a, b T) int) *Stable[T] {
+	s := &Stable[T]{}
+	s.d.cmp = func(a, b stableElement[T]) int {
+		if c := cmp(a.value, b.value); c != 0 {
+			return c
+		}
+		switch {
+		case a.seq < b.seq:
+			return -1
+		case a.seq > b.seq:
+			return 1
+		}
+		return 0
+	}
+	return s
+}
+
+// Len returns the number of elements in the deheap.
+func (s *Stable[T]) Len() int {
+	return s.d.Len()
+}
+
+// Push an element onto the deheap.
+// Time complexity is O(log n), where n = s.Len()
+func (s *Stable[T]) Push(x T) {
+	if s.seq == math.MaxUint64 {
+		s.renumber()
+	}
+	s.d.Push(stableElement[T]{value: x, seq: s.seq})
+	s.seq++
+}
+
+// PeekMin returns the smallest element without removing it.
+func (s *Stable[T]) PeekMin() T {
+	return s.d.PeekMin().value
+}
+
+// PeekMax returns the largest element without removing it.
+func (s *Stable[T]) PeekMax() T {
+	return s.d.PeekMax().value
+}
+
+// PopMin removes and returns the smallest element, the earliest pushed of
+// equal elements.
+// Time complexity is O(log n), where n = s.Len()
+func (s *Stable[T]) PopMin() T {
+	return s.d.PopMin().value
+}
+
+// PopMax removes and returns the largest element, the latest pushed of equal
+// elements.
+// Time complexity is O(log n), where n = s.Len()
+func (s *Stable[T]) PopMax() T {
+	return s.d.PopMax().value
+}
+
+// renumber gives the elements the sequence numbers 0 to n-1 in the order of
+// their current sequence numbers when the counter runs out.  The relative
+// order of the elements is unchanged, so the heap ordering still holds.
+func (s *Stable[T]) renumber() {
+	data := s.d.data
+	idx := make([]int, len(data))
+	for i := range idx {
+		idx[i] = i
+	}
+	sort.Slice(idx, func(i, j int) bool { return data[idx[i]].seq < data[idx[j]].seq })
+	for n, i := range idx {
+		data[i].seq = uint64(n)
+	}
+	s.seq = uint64(len(data))
+}
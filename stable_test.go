@@ -0,0 +1,97 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"math"
+	"testing"
+)
+
+type ticket struct {
+	priority int
+	id       int
+}
+
+func ticketCmp(a, b ticket) int {
+	return intCmp(a.priority, b.priority)
+}
+
+func TestStable(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 100; k++ {
+		st := NewStable(ticketCmp)
+		if k%2 == 0 {
+			// start close to the end of the sequence numbers
+			st.seq = math.MaxUint64 - uint64(s.Intn(20))
+		}
+		N := s.Intn(100) + 1
+		for i := 0; i < N; i++ {
+			st.Push(ticket{priority: s.Intn(5), id: i})
+		}
+		lo := ticket{priority: -1}
+		hi := ticket{priority: 5}
+		for st.Len() > 0 {
+			if s.Intn(2) == 0 {
+				x := st.PopMin()
+				if x.priority < lo.priority || x.priority == lo.priority && x.id < lo.id {
+					t.Fatalf("unexpected value: %v %v", x, lo)
+				}
+				lo = x
+			} else {
+				x := st.PopMax()
+				if x.priority > hi.priority || x.priority == hi.priority && x.id > hi.id {
+					t.Fatalf("unexpected value: %v %v", x, hi)
+				}
+				hi = x
+			}
+		}
+	}
+
+}
+
+func TestStableRenumber(t *testing.T) {
+
+	st := NewStable(ticketCmp)
+	st.seq = math.MaxUint64 - 2
+	for i := 0; i < 6; i++ {
+		st.Push(ticket{priority: 1, id: i})
+	}
+	if st.seq != 6 {
+		t.Fatalf("unexpected value: %d", st.seq)
+	}
+	if x := st.PeekMin(); x.id != 0 {
+		t.Fatalf("unexpected value: %v", x)
+	}
+	if x := st.PeekMax(); x.id != 5 {
+		t.Fatalf("unexpected value: %v", x)
+	}
+	for i := 0; i < 3; i++ {
+		if x := st.PopMin(); x.id != i {
+			t.Fatalf("unexpected value: %v", x)
+		}
+	}
+
+}
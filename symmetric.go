@@ -0,0 +1,249 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"math/bits"
+)
+
+// SymmetricHeap is a type-safe doubly ended heap laid out as a symmetric
+// min-max heap.  It implements PriorityQueue and can be used in place of
+// Deheap.
+//
+// A symmetric min-max heap is a binary tree with an empty root.  Every left
+// child is no greater than its sibling, and the left and right children of
+// each node are the smallest and the largest elements of the subtree below
+// it.  The smallest and the largest elements of the heap are always the
+// first and second elements, and removing either one only ever looks at the
+// left or the right children on the way down.
+type SymmetricHeap[T any] struct {
+	// data[i] is node i+2 of the tree; the root is node 1
+	data []T
+	cmp  func(a, b T) int
+}
+
+// NewSymmetric returns an empty SymmetricHeap ordered by cmp.  See New().
+func NewSymmetric[T any](cmp func(a, b T) int) *SymmetricHeap[T] {
+	return &SymmetricHeap[T]{cmp: cmp}
+}
+
+// Len returns the number of elements in the heap.
+func (d *SymmetricHeap[T]) Len() int {
+	return len(d.data)
+}
+
+// Push an element onto the heap.
+// Time complexity is O(log n), where n = d.Len()
+func (d *SymmetricHeap[T]) Push(x T) {
+	var zero T
+	d.data = append(d.data, zero)
+	p := len(d.data) + 1
+	if p%2 == 1 && d.cmp(x, d.node(p-1)) < 0 {
+		d.set(p, d.node(p-1))
+		p--
+	}
+	for g := p / 4; g > 0; g = p / 4 {
+		if d.cmp(x, d.node(2*g)) < 0 {
+			d.set(p, d.node(2*g))
+			p = 2 * g
+		} else if d.cmp(d.node(2*g+1), x) < 0 {
+			d.set(p, d.node(2*g+1))
+			p = 2*g + 1
+		} else {
+			break
+		}
+	}
+	d.set(p, x)
+	if debug {
+		debugVerifyf(d, "Push(%v)", x)
+	}
+}
+
+// PeekMin returns the smallest element without removing it.
+func (d *SymmetricHeap[T]) PeekMin() T {
+	checkEmpty(len(d.data), "PeekMin")
+	return d.data[0]
+}
+
+// PeekMax returns the largest element without removing it.
+func (d *SymmetricHeap[T]) PeekMax() T {
+	checkEmpty(len(d.data), "PeekMax")
+	if len(d.data) == 1 {
+		return d.data[0]
+	}
+	return d.data[1]
+}
+
+// PopMin removes and returns the smallest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *SymmetricHeap[T]) PopMin() T {
+	checkEmpty(len(d.data), "PopMin")
+	x := d.popMin()
+	if debug {
+		debugVerifyf(d, "PopMin() = %v", x)
+	}
+	return x
+}
+
+// PopMax removes and returns the largest element.
+// Time complexity is O(log n), where n = d.Len()
+func (d *SymmetricHeap[T]) PopMax() T {
+	checkEmpty(len(d.data), "PopMax")
+	x := d.popMax()
+	if debug {
+		debugVerifyf(d, "PopMax() = %v", x)
+	}
+	return x
+}
+
+// popMin removes and returns the smallest element of a heap that is not
+// empty
+func (d *SymmetricHeap[T]) popMin() T {
+	x := d.data[0]
+	y := d.pop()
+	if len(d.data) == 0 {
+		return x
+	}
+	// move the hole at node 2 down the left children
+	n := len(d.data) + 2
+	p := 2
+	for {
+		c := 2 * p
+		if c >= n {
+			break
+		}
+		if c+2 < n && d.cmp(d.node(c+2), d.node(c)) < 0 {
+			c += 2
+		}
+		if d.cmp(y, d.node(c)) <= 0 {
+			break
+		}
+		d.set(p, d.node(c))
+		p = c
+		if p+1 < n && d.cmp(d.node(p+1), y) < 0 {
+			y, d.data[p-1] = d.node(p+1), y
+		}
+	}
+	d.set(p, y)
+	return x
+}
+
+// popMax removes and returns the largest element of a heap that is not
+// empty
+func (d *SymmetricHeap[T]) popMax() T {
+	if len(d.data) == 1 {
+		return d.pop()
+	}
+	x := d.data[1]
+	y := d.pop()
+	if len(d.data) == 1 {
+		return x
+	}
+	// move the hole at node 3 down the right children.  A left child
+	// without a sibling is the largest element of its subtree.
+	n := len(d.data) + 2
+	p := 3
+	for {
+		c := 2*p - 2
+		if c >= n {
+			break
+		}
+		if c+1 < n {
+			c++
+		}
+		if j := min(2*p+1, n-1); j >= 2*p && d.cmp(d.node(c), d.node(j)) < 0 {
+			c = j
+		}
+		if d.cmp(d.node(c), y) <= 0 {
+			break
+		}
+		d.set(p, d.node(c))
+		p = c
+		if p%2 == 0 {
+			break
+		}
+		if d.cmp(y, d.node(p-1)) < 0 {
+			y, d.data[p-3] = d.node(p-1), y
+		}
+	}
+	d.set(p, y)
+	return x
+}
+
+// node returns the element at node p of the tree
+func (d *SymmetricHeap[T]) node(p int) T {
+	return d.data[p-2]
+}
+
+func (d *SymmetricHeap[T]) set(p int, x T) {
+	d.data[p-2] = x
+}
+
+// pop removes and returns the last element
+func (d *SymmetricHeap[T]) pop() T {
+	l := len(d.data) - 1
+	x := d.data[l]
+	var zero T
+	d.data[l] = zero
+	d.data = d.data[:l]
+	return x
+}
+
+// slevel returns the level of the element at index i, counting the
+// children of the empty root as level 0
+func slevel(i int) int {
+	return bits.Len(uint(i+2)) - 2
+}
+
+// Verify checks the ordering of the heap and returns an *InvariantError
+// describing the first violation, or nil if the heap is valid.  A right
+// child that is less than its left sibling is reported as its descendant.
+// Time complexity is O(n), where n = d.Len()
+func (d *SymmetricHeap[T]) Verify() error {
+	for p := 3; p < len(d.data)+2; p++ {
+		if p%2 == 1 && d.cmp(d.node(p), d.node(p-1)) < 0 {
+			return d.invariantError(p-1, p, true)
+		}
+		if g := p / 4; g > 0 {
+			if d.cmp(d.node(p), d.node(2*g)) < 0 {
+				return d.invariantError(2*g, p, true)
+			}
+			if d.cmp(d.node(2*g+1), d.node(p)) < 0 {
+				return d.invariantError(2*g+1, p, false)
+			}
+		}
+	}
+	return nil
+}
+
+// invariantError reports the nodes p and q by their element indexes
+func (d *SymmetricHeap[T]) invariantError(p, q int, min bool) error {
+	return &InvariantError{
+		Ancestor:        p - 2,
+		Descendant:      q - 2,
+		AncestorLevel:   slevel(p - 2),
+		DescendantLevel: slevel(q - 2),
+		Min:             min,
+	}
+}
@@ -0,0 +1,86 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestSymmetricHeap(t *testing.T) {
+
+	d := NewSymmetric(intCmp)
+	for _, x := range []int{5, 3, 8, 1, 9, 2, 7} {
+		d.Push(x)
+	}
+	if err := d.Verify(); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if d.data[0] != 1 || d.data[1] != 9 {
+		t.Fatalf("unexpected value: %v", d.data)
+	}
+
+	for _, want := range []int{1, 2, 3, 5, 7, 8, 9} {
+		if x := d.PopMin(); x != want {
+			t.Fatalf("unexpected value: %d %d", x, want)
+		}
+		if err := d.Verify(); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+	}
+
+}
+
+func TestSymmetricHeapVerify(t *testing.T) {
+
+	d := NewSymmetric(intCmp)
+	d.data = []int{1, 9, 2, 8, 3, 7, 0}
+	err := d.Verify()
+	var ie *InvariantError
+	if !errors.As(err, &ie) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if *ie != (InvariantError{Ancestor: 2, Descendant: 6, AncestorLevel: 1, DescendantLevel: 2, Min: true}) {
+		t.Fatalf("unexpected value: %+v", *ie)
+	}
+
+	d.data = []int{1, 9, 5, 4}
+	err = d.Verify()
+	if !errors.As(err, &ie) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if *ie != (InvariantError{Ancestor: 2, Descendant: 3, AncestorLevel: 1, DescendantLevel: 1, Min: true}) {
+		t.Fatalf("unexpected value: %+v", *ie)
+	}
+
+	d.data = []int{1, 9, 2, 10}
+	err = d.Verify()
+	if !errors.As(err, &ie) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if *ie != (InvariantError{Ancestor: 1, Descendant: 3, AncestorLevel: 0, DescendantLevel: 1}) {
+		t.Fatalf("unexpected value: %+v", *ie)
+	}
+
+}
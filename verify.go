@@ -0,0 +1,102 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"container/heap"
+	"fmt"
+	"sort"
+)
+
+// An InvariantError reports an element that is out of order with one of its
+// ancestors.  Elements on min levels must not be greater than any of their
+// descendants, and elements on max levels must not be less than any of their
+// descendants.
+type InvariantError struct {
+	// Ancestor and Descendant are the indexes of the elements out of order
+	Ancestor   int
+	Descendant int
+	// AncestorLevel and DescendantLevel are the levels of the elements,
+	// counting from the root at level 0
+	AncestorLevel   int
+	DescendantLevel int
+	// Min is true when the ancestor is on a min level and greater than its
+	// descendant, and false when it is on a max level and less than its
+	// descendant
+	Min bool
+}
+
+func (e *InvariantError) Error() string {
+	if e.Min {
+		return fmt.Sprintf("deheap: element %d on min level %d is greater than its descendant %d on level %d",
+			e.Ancestor, e.AncestorLevel, e.Descendant, e.DescendantLevel)
+	}
+	return fmt.Sprintf("deheap: element %d on max level %d is less than its descendant %d on level %d",
+		e.Ancestor, e.AncestorLevel, e.Descendant, e.DescendantLevel)
+}
+
+// Verify checks the ordering of h and returns an *InvariantError describing
+// the violation with the lowest descendant index, or nil if h is a valid
+// deheap.
+// Time complexity is O(n), where n = h.Len()
+func Verify(h heap.Interface) error {
+	return verify(h, h.Len())
+}
+
+// verify checks the first l elements of h against their parents and
+// grandparents, which orders them against all their ancestors
+func verify(h sort.Interface, l int) error {
+	for i := 1; i < l; i++ {
+		min := isMinHeap(i)
+		if p := hparent(i); less(h, min, p, i) {
+			return invariantError(p, i, !min)
+		}
+		if g := parent(i); g >= 0 && less(h, min, i, g) {
+			return invariantError(g, i, min)
+		}
+	}
+	return nil
+}
+
+func invariantError(i, j int, min bool) error {
+	return &InvariantError{
+		Ancestor:        i,
+		Descendant:      j,
+		AncestorLevel:   level(i),
+		DescendantLevel: level(j),
+		Min:             min,
+	}
+}
+
+// Verify checks the ordering of the deheap.  See Verify().
+func (d *Deheap[T]) Verify() error {
+	return verify(d.slice(), len(d.data))
+}
+
+// verifier is a heap with a layout of its own that checks its ordering, for
+// debugVerifyf
+type verifier interface {
+	Len() int
+	Verify() error
+}
@@ -0,0 +1,95 @@
+//
+// Copyright 2026 Aaron H. Alpar
+//
+// Permission is hereby granted, free of charge, to any person obtaining
+// a copy of this software and associated documentation files
+// (the "Software"), to deal in the Software without restriction,
+// including without limitation the rights to use, copy, modify, merge,
+// publish, distribute, sublicense, and/or sell copies of the Software,
+// and to permit persons to whom the Software is furnished to do so,
+// subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included
+// in all copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND,
+// EXPRESS OR IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF
+// MERCHANTABILITY, FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT.
+// IN NO EVENT SHALL THE AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY
+// CLAIM, DAMAGES OR OTHER LIABILITY, WHETHER IN AN ACTION OF CONTRACT,
+// TORT OR OTHERWISE, ARISING FROM, OUT OF OR IN CONNECTION WITH THE
+// SOFTWARE OR THE USE OR OTHER DEALINGS IN THE SOFTWARE.
+//
+
+package deheap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestVerify(t *testing.T) {
+
+	h := &IntHeap{1, 15, 14, 2, 3, 4, 5, 13, 12, 11, 10, 6, 7, 8, 9}
+	if err := Verify(h); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	h = &IntHeap{1, 15, 14, 2, 3, 4, 0}
+	err := Verify(h)
+	var ie *InvariantError
+	if !errors.As(err, &ie) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if *ie != (InvariantError{Ancestor: 0, Descendant: 6, AncestorLevel: 0, DescendantLevel: 2, Min: true}) {
+		t.Fatalf("unexpected value: %+v", ie)
+	}
+
+	h = &IntHeap{1, 15, 14, 2, 16, 4, 5}
+	err = Verify(h)
+	if !errors.As(err, &ie) {
+		t.Fatalf("unexpected value: %v", err)
+	}
+	if *ie != (InvariantError{Ancestor: 1, Descendant: 4, AncestorLevel: 1, DescendantLevel: 2, Min: false}) {
+		t.Fatalf("unexpected value: %+v", ie)
+	}
+	if err.Error() != "deheap: element 1 on max level 1 is less than its descendant 4 on level 2" {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+	h = &IntHeap{3, 1}
+	if err := Verify(h); err == nil {
+		t.Fatalf("unexpected value")
+	}
+
+}
+
+func TestVerifyRandom(t *testing.T) {
+
+	s := _newRand()
+
+	for k := 0; k < 1000; k++ {
+		N := s.Intn(64) + 1
+		h := &IntHeap{}
+		for i := 0; i < N; i++ {
+			Push(h, s.Intn(N))
+		}
+		if err := Verify(h); err != nil {
+			t.Fatalf("unexpected value: %v", err)
+		}
+		(*h)[s.Intn(N)] = s.Intn(N)
+		_, _, ok := isDeheap(t, h)
+		if err := Verify(h); (err == nil) != ok {
+			t.Fatalf("unexpected value: %v %v", err, h)
+		}
+	}
+
+	d := New(intCmp)
+	for i := 0; i < 100; i++ {
+		d.Push(s.Intn(100))
+	}
+	if err := d.Verify(); err != nil {
+		t.Fatalf("unexpected value: %v", err)
+	}
+
+}